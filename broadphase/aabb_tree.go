@@ -0,0 +1,314 @@
+package broadphase
+
+import (
+	"ganymede/object"
+	"ganymede/vector"
+	"sort"
+)
+
+// NewAABBTree creates a broad phase that keeps objects in a balanced tree of bounding boxes.
+// Each object's box is fattened by the margin, so that it can move that far before the tree needs changing.
+func NewAABBTree(margin float64) *AABBTree {
+	return &AABBTree{
+		margin: margin,
+		leaves: map[int]*treeNode{},
+	}
+}
+
+// AABBTree is a broad phase that suits objects of very different sizes.
+// Every node's box encloses the boxes of its two children, so a query only descends into the branches it overlaps.
+// The tree is rebalanced as objects are inserted and removed, keeping its height logarithmic in the number of objects.
+type AABBTree struct {
+	margin float64
+	root   *treeNode
+	leaves map[int]*treeNode
+}
+
+type treeNode struct {
+	// box is fattened for leaves, and encloses both children otherwise
+	box                 object.AABB
+	parent, left, right *treeNode
+	// height is 0 for leaves
+	height int
+
+	// id and tight are only set on leaves, tight being the object's own box
+	id    int
+	tight object.AABB
+}
+
+func (n *treeNode) isLeaf() bool {
+	return n.left == nil
+}
+
+// refit recalculates the box and height of a branch from its children
+func (n *treeNode) refit() {
+	n.box = n.left.box.Union(n.right.box)
+	n.height = 1 + maxInt(n.left.height, n.right.height)
+}
+
+func (n *treeNode) replaceChild(old, replacement *treeNode) {
+	if n.left == old {
+		n.left = replacement
+	} else {
+		n.right = replacement
+	}
+	replacement.parent = n
+}
+
+// Insert adds an object with its bounding box
+func (t *AABBTree) Insert(id int, box object.AABB) {
+	leaf := &treeNode{box: box.Expand(t.margin), id: id, tight: box}
+	t.leaves[id] = leaf
+	t.insertLeaf(leaf)
+}
+
+// Update moves an object's bounding box, only moving it in the tree if it has left its fattened box
+func (t *AABBTree) Update(id int, box object.AABB) {
+	leaf, ok := t.leaves[id]
+	if !ok {
+		t.Insert(id, box)
+		return
+	}
+	leaf.tight = box
+	if leaf.box.Contains(box) {
+		return
+	}
+	t.removeLeaf(leaf)
+	leaf.box = box.Expand(t.margin)
+	t.insertLeaf(leaf)
+}
+
+// Remove removes an object
+func (t *AABBTree) Remove(id int) {
+	leaf, ok := t.leaves[id]
+	if !ok {
+		return
+	}
+	delete(t.leaves, id)
+	t.removeLeaf(leaf)
+}
+
+// Pairs returns every pair of objects whose boxes overlap, sorted by id
+func (t *AABBTree) Pairs() []Pair {
+	pairs := []Pair{}
+	for _, leaf := range t.leaves {
+		t.visit(leaf.box, func(other *treeNode) {
+			// each pair is found from both of its objects, so keep it from the first
+			if leaf.id < other.id && leaf.tight.Overlaps(other.tight) {
+				pairs = append(pairs, Pair{leaf.id, other.id})
+			}
+		})
+	}
+	sortPairs(pairs)
+	return pairs
+}
+
+// Query returns the objects whose boxes overlap the box, sorted by id
+func (t *AABBTree) Query(box object.AABB) []int {
+	ids := []int{}
+	t.visit(box, func(leaf *treeNode) {
+		if leaf.tight.Overlaps(box) {
+			ids = append(ids, leaf.id)
+		}
+	})
+	sort.Ints(ids)
+	return ids
+}
+
+// Raycast returns the objects whose boxes the ray passes through within maxDistance,
+// nearest first by where the ray enters them
+func (t *AABBTree) Raycast(origin, direction vector.Vector, maxDistance float64) []int {
+	if t.root == nil || direction.Magnitude() == 0 {
+		return []int{}
+	}
+	direction = direction.Normalize()
+
+	type hit struct {
+		id       int
+		distance float64
+	}
+	hits := []hit{}
+	stack := []*treeNode{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := n.box.IntersectsRay(origin, direction, maxDistance); !ok {
+			continue
+		}
+		if !n.isLeaf() {
+			stack = append(stack, n.left, n.right)
+			continue
+		}
+		if d, ok := n.tight.IntersectsRay(origin, direction, maxDistance); ok {
+			hits = append(hits, hit{n.id, d})
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].distance != hits[j].distance {
+			return hits[i].distance < hits[j].distance
+		}
+		return hits[i].id < hits[j].id
+	})
+	ids := make([]int, len(hits))
+	for i, h := range hits {
+		ids[i] = h.id
+	}
+	return ids
+}
+
+// visit calls f with every leaf whose fattened box overlaps the box
+func (t *AABBTree) visit(box object.AABB, f func(*treeNode)) {
+	if t.root == nil {
+		return
+	}
+	stack := []*treeNode{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !n.box.Overlaps(box) {
+			continue
+		}
+		if n.isLeaf() {
+			f(n)
+			continue
+		}
+		stack = append(stack, n.left, n.right)
+	}
+}
+
+// insertLeaf places the leaf beside the node where it adds the least to the total perimeter of the tree's boxes
+func (t *AABBTree) insertLeaf(leaf *treeNode) {
+	if t.root == nil {
+		t.root = leaf
+		leaf.parent = nil
+		return
+	}
+
+	sibling := t.root
+	for !sibling.isLeaf() {
+		combined := perimeter(sibling.box.Union(leaf.box))
+		// pairing with the sibling makes a new branch, and growing it grows every branch above
+		cost := 2 * combined
+		inherited := 2 * (combined - perimeter(sibling.box))
+
+		leftCost := descentCost(sibling.left, leaf.box) + inherited
+		rightCost := descentCost(sibling.right, leaf.box) + inherited
+		if cost < leftCost && cost < rightCost {
+			break
+		}
+		if leftCost < rightCost {
+			sibling = sibling.left
+		} else {
+			sibling = sibling.right
+		}
+	}
+
+	oldParent := sibling.parent
+	branch := &treeNode{parent: oldParent, left: sibling, right: leaf}
+	sibling.parent = branch
+	leaf.parent = branch
+	branch.refit()
+	if oldParent == nil {
+		t.root = branch
+	} else {
+		oldParent.replaceChild(sibling, branch)
+	}
+	t.rebalanceFrom(oldParent)
+}
+
+// descentCost is how much the perimeters grow by placing the box somewhere under the node
+func descentCost(n *treeNode, box object.AABB) float64 {
+	combined := perimeter(n.box.Union(box))
+	if n.isLeaf() {
+		return combined
+	}
+	return combined - perimeter(n.box)
+}
+
+// removeLeaf takes the leaf out of the tree, replacing its parent with its sibling
+func (t *AABBTree) removeLeaf(leaf *treeNode) {
+	if leaf == t.root {
+		t.root = nil
+		return
+	}
+
+	parent := leaf.parent
+	sibling := parent.left
+	if sibling == leaf {
+		sibling = parent.right
+	}
+	leaf.parent = nil
+
+	grandparent := parent.parent
+	if grandparent == nil {
+		t.root = sibling
+		sibling.parent = nil
+		return
+	}
+	grandparent.replaceChild(parent, sibling)
+	t.rebalanceFrom(grandparent)
+}
+
+// rebalanceFrom walks up from the node to the root, refitting and rebalancing each branch
+func (t *AABBTree) rebalanceFrom(n *treeNode) {
+	for n != nil {
+		n.refit()
+		n = t.balance(n)
+		n = n.parent
+	}
+}
+
+// balance rotates the taller child of the branch above it if the children's heights differ by more than one,
+// and returns the branch now in its place
+func (t *AABBTree) balance(n *treeNode) *treeNode {
+	if n.isLeaf() || n.height < 2 {
+		return n
+	}
+	switch diff := n.right.height - n.left.height; {
+	case diff > 1:
+		return t.rotate(n, n.right)
+	case diff < -1:
+		return t.rotate(n, n.left)
+	}
+	return n
+}
+
+// rotate lifts the child into its parent's place. The parent becomes one of the child's children,
+// taking the shorter of the child's children in place of the child.
+func (t *AABBTree) rotate(parent, child *treeNode) *treeNode {
+	taller, shorter := child.left, child.right
+	if taller.height < shorter.height {
+		taller, shorter = shorter, taller
+	}
+
+	grandparent := parent.parent
+	if grandparent == nil {
+		t.root = child
+		child.parent = nil
+	} else {
+		grandparent.replaceChild(parent, child)
+	}
+
+	parent.replaceChild(child, shorter)
+	child.left, child.right = parent, taller
+	parent.parent = child
+	taller.parent = child
+
+	parent.refit()
+	child.refit()
+	return child
+}
+
+// perimeter measures a box's size for choosing where to insert, as area would in 3D
+func perimeter(box object.AABB) float64 {
+	min, max := box.Min.GetVals(), box.Max.GetVals()
+	return 2 * (max[0] - min[0] + max[1] - min[1])
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
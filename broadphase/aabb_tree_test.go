@@ -0,0 +1,199 @@
+package broadphase
+
+import (
+	"ganymede/object"
+	"ganymede/vector"
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// checkTree returns the height of the tree under the node,
+// and fails the test if any branch is unbalanced or doesn't enclose its children
+func checkTree(n *treeNode) int {
+	if n.isLeaf() {
+		So(n.height, ShouldEqual, 0)
+		So(n.box.Contains(n.tight), ShouldBeTrue)
+		return 0
+	}
+	So(n.left.parent, ShouldEqual, n)
+	So(n.right.parent, ShouldEqual, n)
+	So(n.box.Contains(n.left.box), ShouldBeTrue)
+	So(n.box.Contains(n.right.box), ShouldBeTrue)
+
+	left, right := checkTree(n.left), checkTree(n.right)
+	So(math.Abs(float64(left-right)), ShouldBeLessThanOrEqualTo, 1)
+	So(n.height, ShouldEqual, 1+maxInt(left, right))
+	return n.height
+}
+
+func TestAABBTree(t *testing.T) {
+	Convey("Should pair overlapping boxes once", t, func() {
+		tree := NewAABBTree(2)
+		tree.Insert(1, box(0, 0, 25, 25))
+		tree.Insert(2, box(20, 20, 45, 45))
+		tree.Insert(3, box(100, 100, 105, 105))
+		So(tree.Pairs(), ShouldResemble, []Pair{{1, 2}})
+	})
+
+	Convey("Should not pair boxes whose fattened boxes overlap but whose boxes don't", t, func() {
+		tree := NewAABBTree(10)
+		tree.Insert(1, box(0, 0, 10, 10))
+		tree.Insert(2, box(15, 0, 25, 10))
+		So(tree.Pairs(), ShouldBeEmpty)
+	})
+
+	Convey("Should pair a huge box with the small ones inside it", t, func() {
+		tree := NewAABBTree(1)
+		tree.Insert(0, box(-1000, -1000, 1000, 1000))
+		tree.Insert(1, box(0, 0, 1, 1))
+		tree.Insert(2, box(5000, 5000, 5001, 5001))
+		So(tree.Pairs(), ShouldResemble, []Pair{{0, 1}})
+	})
+
+	Convey("Should follow boxes as they move", t, func() {
+		tree := NewAABBTree(2)
+		tree.Insert(1, box(0, 0, 5, 5))
+		tree.Insert(2, box(50, 50, 55, 55))
+		So(tree.Pairs(), ShouldBeEmpty)
+
+		tree.Update(2, box(3, 3, 8, 8))
+		So(tree.Pairs(), ShouldResemble, []Pair{{1, 2}})
+
+		tree.Update(2, box(50, 50, 55, 55))
+		So(tree.Pairs(), ShouldBeEmpty)
+	})
+
+	Convey("Should leave the tree alone while boxes stay within their margin", t, func() {
+		tree := NewAABBTree(2)
+		tree.Insert(1, box(0, 0, 5, 5))
+		tree.Insert(2, box(50, 50, 55, 55))
+		root := tree.root
+
+		tree.Update(2, box(51, 51, 56, 56))
+		So(tree.root, ShouldEqual, root)
+		So(tree.Query(box(55.5, 55.5, 60, 60)), ShouldResemble, []int{2})
+	})
+
+	Convey("Should forget removed boxes", t, func() {
+		tree := NewAABBTree(2)
+		tree.Insert(1, box(0, 0, 5, 5))
+		tree.Insert(2, box(1, 1, 6, 6))
+		tree.Remove(2)
+		So(tree.Pairs(), ShouldBeEmpty)
+		So(tree.Query(box(0, 0, 10, 10)), ShouldResemble, []int{1})
+
+		tree.Remove(1)
+		So(tree.root, ShouldBeNil)
+		So(tree.Query(box(0, 0, 10, 10)), ShouldBeEmpty)
+	})
+
+	Convey("Should find the boxes in a region", t, func() {
+		tree := NewAABBTree(2)
+		tree.Insert(3, box(0, 0, 5, 5))
+		tree.Insert(1, box(30, 0, 35, 5))
+		tree.Insert(2, box(60, 0, 65, 5))
+		So(tree.Query(box(4, 0, 31, 1)), ShouldResemble, []int{1, 3})
+	})
+
+	Convey("Should find the boxes along a ray, nearest first", t, func() {
+		tree := NewAABBTree(2)
+		tree.Insert(1, box(60, -5, 65, 5))
+		tree.Insert(2, box(30, -5, 35, 5))
+		tree.Insert(3, box(30, 20, 35, 25))
+		tree.Insert(4, box(-20, -5, -15, 5))
+
+		// the world casts through the optional interface, so the tree must implement it
+		var bp BroadPhase = tree
+		r, ok := bp.(Raycaster)
+		So(ok, ShouldBeTrue)
+		So(r.Raycast(vector.NewVector(0, 0), vector.NewVector(10, 0), 100), ShouldResemble, []int{2, 1})
+		So(r.Raycast(vector.NewVector(0, 0), vector.NewVector(1, 0), 40), ShouldResemble, []int{2})
+		So(r.Raycast(vector.NewVector(0, 0), vector.NewVector(0, 0), 100), ShouldBeEmpty)
+	})
+
+	Convey("Should stay balanced as boxes are inserted in order", t, func() {
+		tree := NewAABBTree(0.5)
+		for i := 0; i < 1024; i++ {
+			x := float64(i) * 10
+			tree.Insert(i, box(x, 0, x+5, 5))
+		}
+		height := checkTree(tree.root)
+		So(height, ShouldBeLessThanOrEqualTo, 2*10)
+	})
+
+	Convey("Should find the same pairs as comparing every box as they move", t, func() {
+		boxes := randomCircles(500)
+		tree := NewAABBTree(2)
+		for id, b := range boxes {
+			tree.Insert(id, b)
+		}
+		r := rand.New(rand.NewSource(3))
+		for step := 0; step < 20; step++ {
+			jiggle(boxes, r)
+			for id, b := range boxes {
+				tree.Update(id, b)
+			}
+		}
+		for id := 0; id < len(boxes); id += 5 {
+			tree.Remove(id)
+			boxes[id] = box(-1e6, -1e6, -1e6, -1e6)
+		}
+		checkTree(tree.root)
+
+		expected := []Pair{}
+		for _, p := range bruteForcePairs(boxes) {
+			if p.A%5 != 0 {
+				expected = append(expected, p)
+			}
+		}
+		So(tree.Pairs(), ShouldResemble, expected)
+	})
+}
+
+func benchmarkAABBTree(b *testing.B, n int) {
+	boxes := randomCircles(n)
+	tree := NewAABBTree(2)
+	for id, box := range boxes {
+		tree.Insert(id, box)
+	}
+	r := rand.New(rand.NewSource(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jiggle(boxes, r)
+		for id, box := range boxes {
+			tree.Update(id, box)
+		}
+		tree.Pairs()
+	}
+}
+
+// mixedSizes returns boxes of circles between 1 and 1000 across, which a grid suits poorly
+func mixedSizes(n int) []object.AABB {
+	r := rand.New(rand.NewSource(1))
+	side := 30 * math.Sqrt(float64(n))
+	boxes := make([]object.AABB, n)
+	for i := range boxes {
+		radius := math.Pow(1000, r.Float64()) / 2
+		c := object.NewCircleObject(radius, 1, vector.NewVector(r.Float64()*side, r.Float64()*side))
+		boxes[i] = c.GetAABB()
+	}
+	return boxes
+}
+
+func benchmarkMixed(b *testing.B, bp BroadPhase) {
+	for id, box := range mixedSizes(2000) {
+		bp.Insert(id, box)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bp.Pairs()
+	}
+}
+
+func BenchmarkAABBTree1k(b *testing.B)       { benchmarkAABBTree(b, 1000) }
+func BenchmarkAABBTree10k(b *testing.B)      { benchmarkAABBTree(b, 10000) }
+func BenchmarkAABBTreeMixed(b *testing.B)    { benchmarkMixed(b, NewAABBTree(2)) }
+func BenchmarkSpatialHashMixed(b *testing.B) { benchmarkMixed(b, NewSpatialHash(20)) }
@@ -0,0 +1,51 @@
+// Package broadphase finds the pairs of objects that might be colliding, by their bounding boxes,
+// so that only those pairs need testing with object.DetectCollision
+package broadphase
+
+import (
+	"ganymede/object"
+	"ganymede/vector"
+	"sort"
+)
+
+// BroadPhase tracks the bounding boxes of objects, identified by ids chosen by the caller
+type BroadPhase interface {
+	// Insert adds an object with its bounding box
+	Insert(id int, box object.AABB)
+	// Update moves an object's bounding box
+	Update(id int, box object.AABB)
+	// Remove removes an object
+	Remove(id int)
+	// Pairs returns every pair of objects whose boxes overlap, sorted by id
+	Pairs() []Pair
+	// Query returns the objects whose boxes overlap the box, sorted by id
+	Query(box object.AABB) []int
+}
+
+// Raycaster is implemented by broad phases that can find the objects along a ray
+// more directly than by querying the box around it
+type Raycaster interface {
+	// Raycast returns the objects whose boxes the ray passes through within maxDistance
+	Raycast(origin, direction vector.Vector, maxDistance float64) []int
+}
+
+// Pair is two objects whose bounding boxes overlap. A is always less than B.
+type Pair struct {
+	A, B int
+}
+
+func newPair(a, b int) Pair {
+	if a > b {
+		a, b = b, a
+	}
+	return Pair{a, b}
+}
+
+func sortPairs(pairs []Pair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+}
@@ -0,0 +1,138 @@
+package broadphase
+
+import (
+	"ganymede/object"
+	"math"
+	"sort"
+)
+
+// NewSpatialHash creates a broad phase that files objects into a uniform grid of square cells.
+// Cells a little larger than most objects work best: smaller cells file objects into more of them,
+// and larger cells hold more objects that don't overlap.
+// It panics if the cell size isn't positive.
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	if !(cellSize > 0) {
+		panic("Spatial hash cell size must be positive")
+	}
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    map[cell][]int{},
+		boxes:    map[int]object.AABB{},
+	}
+}
+
+// SpatialHash is a broad phase that only compares objects sharing a cell of a grid.
+// Only the cells that hold objects are stored, so the grid is unbounded.
+type SpatialHash struct {
+	cellSize float64
+	cells    map[cell][]int
+	boxes    map[int]object.AABB
+}
+
+type cell struct {
+	x, y int
+}
+
+// cellRange is the first and last cells a box covers
+type cellRange struct {
+	min, max cell
+}
+
+// Insert adds an object with its bounding box
+func (h *SpatialHash) Insert(id int, box object.AABB) {
+	h.boxes[id] = box
+	h.forEachCell(h.cellsOf(box), func(c cell) {
+		h.cells[c] = append(h.cells[c], id)
+	})
+}
+
+// Update moves an object's bounding box, refiling it only if it has moved into different cells
+func (h *SpatialHash) Update(id int, box object.AABB) {
+	old, ok := h.boxes[id]
+	if !ok {
+		h.Insert(id, box)
+		return
+	}
+	if h.cellsOf(old) == h.cellsOf(box) {
+		h.boxes[id] = box
+		return
+	}
+	h.Remove(id)
+	h.Insert(id, box)
+}
+
+// Remove removes an object
+func (h *SpatialHash) Remove(id int) {
+	box, ok := h.boxes[id]
+	if !ok {
+		return
+	}
+	delete(h.boxes, id)
+	h.forEachCell(h.cellsOf(box), func(c cell) {
+		ids := h.cells[c]
+		for i, existing := range ids {
+			if existing == id {
+				ids = append(ids[:i], ids[i+1:]...)
+				break
+			}
+		}
+		if len(ids) == 0 {
+			delete(h.cells, c)
+			return
+		}
+		h.cells[c] = ids
+	})
+}
+
+// Pairs returns every pair of objects whose boxes overlap, sorted by id
+func (h *SpatialHash) Pairs() []Pair {
+	found := map[Pair]bool{}
+	pairs := []Pair{}
+	for _, ids := range h.cells {
+		for i, a := range ids {
+			for _, b := range ids[i+1:] {
+				p := newPair(a, b)
+				if found[p] || !h.boxes[a].Overlaps(h.boxes[b]) {
+					continue
+				}
+				found[p] = true
+				pairs = append(pairs, p)
+			}
+		}
+	}
+	sortPairs(pairs)
+	return pairs
+}
+
+// Query returns the objects whose boxes overlap the box, sorted by id
+func (h *SpatialHash) Query(box object.AABB) []int {
+	found := map[int]bool{}
+	ids := []int{}
+	h.forEachCell(h.cellsOf(box), func(c cell) {
+		for _, id := range h.cells[c] {
+			if found[id] || !h.boxes[id].Overlaps(box) {
+				continue
+			}
+			found[id] = true
+			ids = append(ids, id)
+		}
+	})
+	sort.Ints(ids)
+	return ids
+}
+
+func (h *SpatialHash) cellsOf(box object.AABB) cellRange {
+	return cellRange{h.cellOf(box.Min.GetVals()), h.cellOf(box.Max.GetVals())}
+}
+
+func (h *SpatialHash) cellOf(point []float64) cell {
+	return cell{int(math.Floor(point[0] / h.cellSize)), int(math.Floor(point[1] / h.cellSize))}
+}
+
+func (h *SpatialHash) forEachCell(r cellRange, f func(cell)) {
+	for x := r.min.x; x <= r.max.x; x++ {
+		for y := r.min.y; y <= r.max.y; y++ {
+			f(cell{x, y})
+		}
+	}
+}
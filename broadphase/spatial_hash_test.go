@@ -0,0 +1,148 @@
+package broadphase
+
+import (
+	"ganymede/object"
+	"ganymede/vector"
+	"math"
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func box(minX, minY, maxX, maxY float64) object.AABB {
+	return object.AABB{Min: vector.NewVector(minX, minY), Max: vector.NewVector(maxX, maxY)}
+}
+
+func TestSpatialHash(t *testing.T) {
+	Convey("Should refuse cells without a positive size", t, func() {
+		So(func() { NewSpatialHash(0) }, ShouldPanic)
+		So(func() { NewSpatialHash(-10) }, ShouldPanic)
+		So(func() { NewSpatialHash(math.NaN()) }, ShouldPanic)
+	})
+
+	Convey("Should pair overlapping boxes once", t, func() {
+		h := NewSpatialHash(10)
+		h.Insert(1, box(0, 0, 25, 25))
+		h.Insert(2, box(20, 20, 45, 45))
+		h.Insert(3, box(100, 100, 105, 105))
+		So(h.Pairs(), ShouldResemble, []Pair{{1, 2}})
+	})
+
+	Convey("Should not pair boxes that share a cell without overlapping", t, func() {
+		h := NewSpatialHash(100)
+		h.Insert(1, box(0, 0, 10, 10))
+		h.Insert(2, box(20, 20, 30, 30))
+		So(h.Pairs(), ShouldBeEmpty)
+	})
+
+	Convey("Should handle negative coordinates", t, func() {
+		h := NewSpatialHash(10)
+		h.Insert(1, box(-15, -15, -5, -5))
+		h.Insert(2, box(-6, -6, 5, 5))
+		So(h.Pairs(), ShouldResemble, []Pair{{1, 2}})
+	})
+
+	Convey("Should follow boxes as they move", t, func() {
+		h := NewSpatialHash(10)
+		h.Insert(1, box(0, 0, 5, 5))
+		h.Insert(2, box(50, 50, 55, 55))
+		So(h.Pairs(), ShouldBeEmpty)
+
+		h.Update(2, box(3, 3, 8, 8))
+		So(h.Pairs(), ShouldResemble, []Pair{{1, 2}})
+
+		h.Update(2, box(50, 50, 55, 55))
+		So(h.Pairs(), ShouldBeEmpty)
+	})
+
+	Convey("Should forget removed boxes", t, func() {
+		h := NewSpatialHash(10)
+		h.Insert(1, box(0, 0, 5, 5))
+		h.Insert(2, box(1, 1, 6, 6))
+		h.Remove(2)
+		So(h.Pairs(), ShouldBeEmpty)
+		So(h.Query(box(0, 0, 10, 10)), ShouldResemble, []int{1})
+	})
+
+	Convey("Should find the boxes in a region", t, func() {
+		h := NewSpatialHash(10)
+		h.Insert(3, box(0, 0, 5, 5))
+		h.Insert(1, box(30, 0, 35, 5))
+		h.Insert(2, box(60, 0, 65, 5))
+		So(h.Query(box(4, 0, 31, 1)), ShouldResemble, []int{1, 3})
+	})
+
+	Convey("Should find the same pairs as comparing every box", t, func() {
+		boxes := randomCircles(500)
+		h := NewSpatialHash(20)
+		for id, b := range boxes {
+			h.Insert(id, b)
+		}
+		So(h.Pairs(), ShouldResemble, bruteForcePairs(boxes))
+	})
+}
+
+// randomCircles returns the boxes around n circles scattered at the same density however many there are
+func randomCircles(n int) []object.AABB {
+	r := rand.New(rand.NewSource(1))
+	side := 30 * math.Sqrt(float64(n))
+	boxes := make([]object.AABB, n)
+	for i := range boxes {
+		c := object.NewCircleObject(5, 1, vector.NewVector(r.Float64()*side, r.Float64()*side))
+		boxes[i] = c.GetAABB()
+	}
+	return boxes
+}
+
+func bruteForcePairs(boxes []object.AABB) []Pair {
+	pairs := []Pair{}
+	for i := range boxes {
+		for j := i + 1; j < len(boxes); j++ {
+			if boxes[i].Overlaps(boxes[j]) {
+				pairs = append(pairs, Pair{i, j})
+			}
+		}
+	}
+	return pairs
+}
+
+// jiggle moves every box a little, as the objects would between steps
+func jiggle(boxes []object.AABB, r *rand.Rand) {
+	for i, b := range boxes {
+		d := vector.NewVector(r.Float64()-0.5, r.Float64()-0.5)
+		boxes[i] = object.AABB{Min: b.Min.Add(d), Max: b.Max.Add(d)}
+	}
+}
+
+func benchmarkSpatialHash(b *testing.B, n int) {
+	boxes := randomCircles(n)
+	h := NewSpatialHash(20)
+	for id, box := range boxes {
+		h.Insert(id, box)
+	}
+	r := rand.New(rand.NewSource(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jiggle(boxes, r)
+		for id, box := range boxes {
+			h.Update(id, box)
+		}
+		h.Pairs()
+	}
+}
+
+func benchmarkBruteForce(b *testing.B, n int) {
+	boxes := randomCircles(n)
+	r := rand.New(rand.NewSource(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jiggle(boxes, r)
+		bruteForcePairs(boxes)
+	}
+}
+
+func BenchmarkSpatialHash1k(b *testing.B)  { benchmarkSpatialHash(b, 1000) }
+func BenchmarkSpatialHash10k(b *testing.B) { benchmarkSpatialHash(b, 10000) }
+func BenchmarkBruteForce1k(b *testing.B)   { benchmarkBruteForce(b, 1000) }
+func BenchmarkBruteForce10k(b *testing.B)  { benchmarkBruteForce(b, 10000) }
@@ -0,0 +1,148 @@
+package broadphase
+
+import (
+	"ganymede/object"
+	"sort"
+)
+
+// NewSweepAndPrune creates a broad phase that sorts objects along the x axis
+func NewSweepAndPrune() *SweepAndPrune {
+	return &SweepAndPrune{boxes: map[int]object.AABB{}}
+}
+
+// SweepAndPrune is a broad phase that keeps the ends of every object's box sorted along the x axis,
+// then sweeps along them, only comparing objects whose extents along x overlap.
+// The ends stay sorted between steps and are re-sorted with insertion sort, which is fast when objects have only moved a little.
+// It suits scenes spread out along x, such as side-scrollers.
+type SweepAndPrune struct {
+	endpoints []endpoint
+	boxes     map[int]object.AABB
+	inserted  bool // since the ends were last sorted
+}
+
+// endpoint is the left or right end of an object's box
+type endpoint struct {
+	x     float64
+	id    int
+	isMin bool
+}
+
+// before orders endpoints along x, with left ends first where they meet so that touching boxes overlap
+func (e endpoint) before(other endpoint) bool {
+	if e.x != other.x {
+		return e.x < other.x
+	}
+	return e.isMin && !other.isMin
+}
+
+// Insert adds an object with its bounding box
+func (s *SweepAndPrune) Insert(id int, box object.AABB) {
+	s.boxes[id] = box
+	s.endpoints = append(s.endpoints, endpoint{id: id, isMin: true}, endpoint{id: id})
+	s.inserted = true
+}
+
+// Update moves an object's bounding box. The ends are re-sorted when the pairs are next needed.
+func (s *SweepAndPrune) Update(id int, box object.AABB) {
+	if _, ok := s.boxes[id]; !ok {
+		s.Insert(id, box)
+		return
+	}
+	s.boxes[id] = box
+}
+
+// Remove removes an object
+func (s *SweepAndPrune) Remove(id int) {
+	if _, ok := s.boxes[id]; !ok {
+		return
+	}
+	delete(s.boxes, id)
+	remaining := s.endpoints[:0]
+	for _, e := range s.endpoints {
+		if e.id != id {
+			remaining = append(remaining, e)
+		}
+	}
+	s.endpoints = remaining
+}
+
+// Pairs returns every pair of objects whose boxes overlap, sorted by id
+func (s *SweepAndPrune) Pairs() []Pair {
+	s.sort()
+	type activeObject struct {
+		id  int
+		box object.AABB
+	}
+	pairs := []Pair{}
+	active := []activeObject{}
+	for _, e := range s.endpoints {
+		if !e.isMin {
+			for i, a := range active {
+				if a.id == e.id {
+					active = append(active[:i], active[i+1:]...)
+					break
+				}
+			}
+			continue
+		}
+
+		// every active object overlaps this one along x
+		box := s.boxes[e.id]
+		for _, a := range active {
+			if a.box.Overlaps(box) {
+				pairs = append(pairs, newPair(a.id, e.id))
+			}
+		}
+		active = append(active, activeObject{e.id, box})
+	}
+	sortPairs(pairs)
+	return pairs
+}
+
+// Query returns the objects whose boxes overlap the box, sorted by id
+func (s *SweepAndPrune) Query(box object.AABB) []int {
+	s.sort()
+	maxX := box.Max.GetVals()[0]
+	ids := []int{}
+	for _, e := range s.endpoints {
+		if e.x > maxX {
+			break
+		}
+		if e.isMin && s.boxes[e.id].Overlaps(box) {
+			ids = append(ids, e.id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// sort refreshes the ends from the objects' boxes and insertion sorts them,
+// which takes little more than one pass while the objects keep their order.
+// New objects' ends could be anywhere, so after insertions they are sorted from scratch.
+func (s *SweepAndPrune) sort() {
+	for i, e := range s.endpoints {
+		box := s.boxes[e.id]
+		if e.isMin {
+			s.endpoints[i].x = box.Min.GetVals()[0]
+		} else {
+			s.endpoints[i].x = box.Max.GetVals()[0]
+		}
+	}
+
+	if s.inserted {
+		s.inserted = false
+		sort.Slice(s.endpoints, func(i, j int) bool {
+			return s.endpoints[i].before(s.endpoints[j])
+		})
+		return
+	}
+
+	for i := 1; i < len(s.endpoints); i++ {
+		e := s.endpoints[i]
+		j := i
+		for ; j > 0 && e.before(s.endpoints[j-1]); j-- {
+			s.endpoints[j] = s.endpoints[j-1]
+		}
+		s.endpoints[j] = e
+	}
+}
@@ -0,0 +1,132 @@
+package broadphase
+
+import (
+	"ganymede/object"
+	"ganymede/vector"
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSweepAndPrune(t *testing.T) {
+	Convey("Should pair overlapping boxes once", t, func() {
+		s := NewSweepAndPrune()
+		s.Insert(1, box(0, 0, 25, 25))
+		s.Insert(2, box(20, 20, 45, 45))
+		s.Insert(3, box(100, 100, 105, 105))
+		So(s.Pairs(), ShouldResemble, []Pair{{1, 2}})
+	})
+
+	Convey("Should not pair boxes that only overlap along x", t, func() {
+		s := NewSweepAndPrune()
+		s.Insert(1, box(0, 0, 10, 10))
+		s.Insert(2, box(5, 20, 15, 30))
+		So(s.Pairs(), ShouldBeEmpty)
+	})
+
+	Convey("Should pair boxes that touch", t, func() {
+		s := NewSweepAndPrune()
+		s.Insert(1, box(0, 0, 10, 10))
+		s.Insert(2, box(10, 0, 20, 10))
+		So(s.Pairs(), ShouldResemble, []Pair{{1, 2}})
+	})
+
+	Convey("Should follow boxes as they pass each other", t, func() {
+		s := NewSweepAndPrune()
+		s.Insert(1, box(0, 0, 5, 5))
+		s.Insert(2, box(50, 0, 55, 5))
+		So(s.Pairs(), ShouldBeEmpty)
+
+		s.Update(2, box(3, 0, 8, 5))
+		So(s.Pairs(), ShouldResemble, []Pair{{1, 2}})
+
+		s.Update(2, box(-50, 0, -45, 5))
+		So(s.Pairs(), ShouldBeEmpty)
+		So(s.Query(box(-100, 0, 1, 1)), ShouldResemble, []int{1, 2})
+	})
+
+	Convey("Should forget removed boxes", t, func() {
+		s := NewSweepAndPrune()
+		s.Insert(1, box(0, 0, 5, 5))
+		s.Insert(2, box(1, 1, 6, 6))
+		s.Remove(2)
+		So(s.Pairs(), ShouldBeEmpty)
+		So(s.Query(box(0, 0, 10, 10)), ShouldResemble, []int{1})
+	})
+
+	Convey("Should find the boxes in a region", t, func() {
+		s := NewSweepAndPrune()
+		s.Insert(3, box(0, 0, 5, 5))
+		s.Insert(1, box(30, 0, 35, 5))
+		s.Insert(2, box(60, 0, 65, 5))
+		So(s.Query(box(4, 0, 31, 1)), ShouldResemble, []int{1, 3})
+	})
+
+	Convey("Should find the same pairs as comparing every box as they move", t, func() {
+		boxes := randomCircles(500)
+		s := NewSweepAndPrune()
+		for id, b := range boxes {
+			s.Insert(id, b)
+		}
+		r := rand.New(rand.NewSource(3))
+		for step := 0; step < 20; step++ {
+			jiggle(boxes, r)
+			for id, b := range boxes {
+				s.Update(id, b)
+			}
+			So(s.Pairs(), ShouldResemble, bruteForcePairs(boxes))
+		}
+	})
+}
+
+func benchmarkSweepAndPrune(b *testing.B, boxes []object.AABB) {
+	s := NewSweepAndPrune()
+	for id, box := range boxes {
+		s.Insert(id, box)
+	}
+	r := rand.New(rand.NewSource(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jiggle(boxes, r)
+		for id, box := range boxes {
+			s.Update(id, box)
+		}
+		s.Pairs()
+	}
+}
+
+// sideScroller returns boxes of circles spread along a level 100 times wider than it is tall
+func sideScroller(n int) []object.AABB {
+	r := rand.New(rand.NewSource(1))
+	width := 3000 * float64(n) / 1000
+	boxes := make([]object.AABB, n)
+	for i := range boxes {
+		c := object.NewCircleObject(5, 1, vector.NewVector(r.Float64()*width, r.Float64()*width/100))
+		boxes[i] = c.GetAABB()
+	}
+	return boxes
+}
+
+func benchmarkSideScroller(b *testing.B, bp BroadPhase) {
+	boxes := sideScroller(10000)
+	for id, box := range boxes {
+		bp.Insert(id, box)
+	}
+	r := rand.New(rand.NewSource(2))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jiggle(boxes, r)
+		for id, box := range boxes {
+			bp.Update(id, box)
+		}
+		bp.Pairs()
+	}
+}
+
+func BenchmarkSweepAndPrune1k(b *testing.B)  { benchmarkSweepAndPrune(b, randomCircles(1000)) }
+func BenchmarkSweepAndPrune10k(b *testing.B) { benchmarkSweepAndPrune(b, randomCircles(10000)) }
+func BenchmarkSweepAndPruneSideScroller(b *testing.B) {
+	benchmarkSideScroller(b, NewSweepAndPrune())
+}
+func BenchmarkSpatialHashSideScroller(b *testing.B) { benchmarkSideScroller(b, NewSpatialHash(20)) }
@@ -0,0 +1,115 @@
+package ganymede
+
+import (
+	"ganymede/object"
+	"ganymede/vector"
+)
+
+// maxBulletSubSteps bounds how many times a bullet can be stopped and sent on again within a step
+const maxBulletSubSteps = 8
+
+// positionsBeforeStep records where every body is before it moves, if there are any bullets to sweep
+func (w *World) positionsBeforeStep() map[Body]vector.Vector {
+	hasBullets := false
+	for _, b := range w.bodies {
+		hasBullets = hasBullets || b.IsBullet()
+	}
+	if !hasBullets {
+		return nil
+	}
+
+	starts := make(map[Body]vector.Vector, len(w.bodies))
+	for _, b := range w.bodies {
+		starts[b] = b.GetPosition()
+	}
+	return starts
+}
+
+// sweepBullets sweeps each dynamic bullet along the path it took this step, rather than only testing where it ended up.
+// Bullets must be convex, and are swept without turning.
+func (w *World) sweepBullets(starts map[Body]vector.Vector, dt float64) {
+	for _, b := range w.bodies {
+		if !b.IsBullet() || b.GetKind() != object.Dynamic {
+			continue
+		}
+		shape, ok := b.(object.Convex)
+		if !ok {
+			continue
+		}
+
+		w.sweepBullet(b, shape, starts, dt)
+		if w.broadPhase != nil {
+			w.broadPhase.Update(w.ids[b], b.GetAABB())
+		}
+	}
+}
+
+// sweepBullet moves the bullet back to where it started the step and sweeps it along its path.
+// At the first body in the way the bullet is stopped where they touch, and a contact between them is solved.
+// The bullet is then swept on at its new velocity for the rest of the step, relative to the body it touched.
+func (w *World) sweepBullet(b Body, shape object.Convex, starts map[Body]vector.Vector, dt float64) {
+	displacement := b.GetPosition().Subtract(starts[b])
+	b.AdjustPosition(displacement.Scale(-1))
+
+	elapsed := 0.0 // as a fraction of the step
+	for i := 0; i < maxBulletSubSteps; i++ {
+		other, hit, toContact, ok := w.timeOfImpact(b, shape, displacement, starts, elapsed)
+		if !ok {
+			b.AdjustPosition(displacement)
+			return
+		}
+
+		b.AdjustPosition(toContact)
+		w.solveImpact(b, other, hit)
+		elapsed += (1 - elapsed) * hit.Fraction
+		// the bullet was placed against where the body ends the step, so only their relative motion is left
+		displacement = b.GetVelocity().Subtract(other.GetVelocity()).Scale((1 - elapsed) * dt)
+	}
+	// out of sub-steps, so leave the bullet where it last touched something
+}
+
+// timeOfImpact finds the first body the bullet touches as it moves by the displacement over the rest of the step.
+// Other bodies are moved back to where they were at the elapsed fraction of the step,
+// and the bullet is swept by its motion relative to them.
+// As the body stays where it ends the step, it also returns how far to move the bullet to touch it there.
+func (w *World) timeOfImpact(b Body, shape object.Convex, displacement vector.Vector, starts map[Body]vector.Vector, elapsed float64) (Body, object.ShapeCastHit, vector.Vector, bool) {
+	var first Body
+	var best object.ShapeCastHit
+	var toContact vector.Vector
+	for _, other := range w.queryCandidates(object.SweptAABB(shape, displacement)) {
+		if other == b {
+			continue
+		}
+
+		remaining := other.GetPosition().Subtract(starts[other]).Scale(1 - elapsed)
+		other.AdjustPosition(remaining.Scale(-1))
+		relative := displacement.Subtract(remaining)
+		hit, ok := object.ShapeCast(shape, relative, other)
+		other.AdjustPosition(remaining)
+
+		// bodies the bullet is already moving away from aren't in the way. The normal is the surface's own,
+		// even where the bullet starts touching it, so a bullet sliding along the ground doesn't stop
+		if !ok || hit.Normal.DotProduct(relative) >= 0 {
+			continue
+		}
+		if first == nil || hit.Fraction < best.Fraction {
+			hit.Point = hit.Point.Add(remaining)
+			first, best = other, hit
+			toContact = relative.Scale(hit.Fraction).Add(remaining)
+		}
+	}
+	return first, best, toContact, first != nil
+}
+
+// solveImpact solves the velocities of a contact between the bullet and the body it has just touched
+func (w *World) solveImpact(b, other Body, hit object.ShapeCastHit) {
+	c := newContact(b, other, object.Manifold{
+		Normal:    hit.Normal.Scale(-1),
+		Points:    []vector.Vector{hit.Point},
+		SubShapeB: hit.SubShape,
+	})
+	c.prepare(w.restitutionThreshold)
+	for i := 0; i < w.solverIterations; i++ {
+		c.solve()
+	}
+}
@@ -0,0 +1,105 @@
+package ganymede
+
+import (
+	"ganymede/broadphase"
+	"ganymede/force"
+	"ganymede/object"
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBullets(t *testing.T) {
+	// a small circle fired at 60 px a step, much further than its own size, at a 5 px wall
+	fire := func(bullet bool, bp broadphase.BroadPhase, restitution float64) *object.Circle {
+		w := NewWorld()
+		w.SetBroadPhase(bp)
+		ball := object.NewCircleObject(2, 1, vector.NewVector(0, 0))
+		ball.SetVelocity(vector.NewVector(3600, 0))
+		ball.SetRestitution(restitution)
+		ball.SetBullet(bullet)
+		wall := object.NewRectangleObject(5, 200, 0, vector.NewVector(100, -100))
+		w.AddBody(&ball)
+		w.AddBody(&wall)
+
+		for i := 0; i < 10; i++ {
+			w.Step(1.0 / 60)
+		}
+		return &ball
+	}
+
+	Convey("Should pass straight through a thin wall without being a bullet", t, func() {
+		ball := fire(false, nil, 0)
+		So(ball.GetPosition().GetVals()[0], ShouldBeGreaterThan, 105)
+	})
+
+	Convey("Should stop a bullet at a thin wall", t, func() {
+		ball := fire(true, nil, 0)
+		So(ball.GetPosition().GetVals()[0], ShouldAlmostEqual, 98, 0.1)
+		So(ball.GetVelocity().GetVals()[0], ShouldAlmostEqual, 0)
+	})
+
+	Convey("Should bounce a bullet off a thin wall within the step it hits", t, func() {
+		ball := fire(true, broadphase.NewAABBTree(5), 1)
+		So(ball.GetPosition().GetVals()[0], ShouldBeLessThan, 0)
+		So(ball.GetVelocity().GetVals()[0], ShouldAlmostEqual, -3600)
+	})
+
+	Convey("Should stop a bullet at a segment with no thickness", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(2, 1, vector.NewVector(0, 0))
+		ball.SetVelocity(vector.NewVector(3600, 0))
+		ball.SetBullet(true)
+		segment := object.NewSegmentObject(vector.NewVector(100, -100), vector.NewVector(100, 100))
+		w.AddBody(&ball)
+		w.AddBody(&segment)
+
+		for i := 0; i < 10; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(ball.GetPosition().GetVals()[0], ShouldBeLessThan, 100)
+	})
+
+	Convey("Should keep a bullet's speed along a floor it rolls on", t, func() {
+		roll := func(bullet bool) *object.Circle {
+			w := NewWorld()
+			ball := object.NewCircleObject(20, 1, vector.NewVector(400, 120))
+			ball.SetVelocity(vector.NewVector(100, 0))
+			ball.SetFriction(0.2)
+			ball.SetBullet(bullet)
+			platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, 0))
+			w.AddBody(&ball)
+			w.AddBody(&platform)
+			w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+			for i := 0; i < 30; i++ {
+				w.Step(1.0 / 60)
+			}
+			return &ball
+		}
+
+		bullet, body := roll(true), roll(false)
+		So(bullet.GetVelocity().GetVals()[0], ShouldAlmostEqual, body.GetVelocity().GetVals()[0], 5)
+		So(bullet.GetAngularVelocity(), ShouldAlmostEqual, body.GetAngularVelocity(), 0.5)
+		So(bullet.GetPosition().GetVals()[0], ShouldBeGreaterThan, 430)
+	})
+
+	Convey("Should hit a thin body moving towards the bullet", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(2, 1, vector.NewVector(0, 0))
+		ball.SetVelocity(vector.NewVector(3600, 0))
+		ball.SetBullet(true)
+		plate := object.NewRectangleObject(5, 200, 100, vector.NewVector(150, -100))
+		plate.SetVelocity(vector.NewVector(-600, 0))
+		w.AddBody(&ball)
+		w.AddBody(&plate)
+
+		for i := 0; i < 10; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(ball.GetPosition().GetVals()[0], ShouldBeLessThan, plate.GetPosition().GetVals()[0])
+		So(ball.GetVelocity().GetVals()[0], ShouldBeLessThan, plate.GetVelocity().GetVals()[0]+1e-6)
+		So(plate.GetVelocity().GetVals()[0], ShouldBeGreaterThan, -600)
+	})
+}
@@ -0,0 +1,239 @@
+package ganymede
+
+import (
+	"ganymede/object"
+	"ganymede/vector"
+	"math"
+)
+
+// maxBlockConditionNumber limits how ill-conditioned a two point contact can be before
+// its points are solved one at a time instead
+const maxBlockConditionNumber = 1000
+
+// warmStartAlignment is how closely a contact's normal must match the previous step's to reuse its impulses
+const warmStartAlignment = 0.99
+
+// contact is a collision between two bodies that the solver resolves with impulses
+type contact struct {
+	a, b   Body
+	key    contactKey
+	normal vector.Vector // unit vector pointing from a to b
+	depth  float64
+	points []*contactPoint
+
+	// the centres of the bodies when the contact was found, to track the overlap as they are pushed apart
+	centreA, centreB vector.Vector
+
+	restitution float64
+	friction    float64
+}
+
+// contactKey identifies the pair of bodies in a contact and the parts of them that touch,
+// so it can be matched between steps
+type contactKey struct {
+	a, b                 Body
+	subShapeA, subShapeB int
+}
+
+// contactPoint holds the solver state for one point of a contact
+type contactPoint struct {
+	position vector.Vector
+	rA, rB   vector.Vector // from the centre of each body to the point
+
+	normalMass  float64
+	tangentMass float64
+	bounce      float64 // normal velocity the solver aims for after restitution
+
+	normalImpulse  float64 // accumulated over iterations
+	tangentImpulse float64
+}
+
+func newContact(a, b Body, m object.Manifold) *contact {
+	c := &contact{
+		a:           a,
+		b:           b,
+		key:         contactKey{a, b, m.SubShapeA, m.SubShapeB},
+		normal:      m.Normal,
+		depth:       m.Depth,
+		centreA:     a.GetCentre(),
+		centreB:     b.GetCentre(),
+		restitution: math.Max(a.GetRestitution(), b.GetRestitution()),
+		friction:    math.Sqrt(a.GetFriction() * b.GetFriction()),
+	}
+	for _, p := range m.Points {
+		c.points = append(c.points, &contactPoint{position: p})
+	}
+	return c
+}
+
+// warmStart carries the impulses accumulated by the same contact in the previous step over to this one,
+// and applies them before the solver iterates. Resting contacts then start close to their solution,
+// which keeps stacks from drifting.
+func (c *contact) warmStart(previous *contact) {
+	if previous == nil || len(previous.points) != len(c.points) || previous.normal.DotProduct(c.normal) < warmStartAlignment {
+		return
+	}
+
+	tangent := c.normal.Perpendicular()
+	for i, p := range c.points {
+		p.normalImpulse = previous.points[i].normalImpulse
+		p.tangentImpulse = previous.points[i].tangentImpulse
+		c.applyImpulse(p, c.normal.Scale(p.normalImpulse).Add(tangent.Scale(p.tangentImpulse)))
+	}
+}
+
+// prepare computes the effective masses and restitution targets for each point
+// before the solver iterates
+func (c *contact) prepare(restitutionThreshold float64) {
+	tangent := c.normal.Perpendicular()
+	for _, p := range c.points {
+		p.rA = p.position.Subtract(c.a.GetCentre())
+		p.rB = p.position.Subtract(c.b.GetCentre())
+		p.normalMass = c.effectiveMass(p, c.normal)
+		p.tangentMass = c.effectiveMass(p, tangent)
+
+		p.bounce = 0
+		approachSpeed := -c.relativeVelocity(p).DotProduct(c.normal)
+		if approachSpeed > restitutionThreshold {
+			p.bounce = c.restitution * approachSpeed
+		}
+	}
+}
+
+// effectiveMass returns the mass the pair of bodies presents to an impulse along the direction at the point
+func (c *contact) effectiveMass(p *contactPoint, direction vector.Vector) float64 {
+	rnA := p.rA.PerpDotProduct(direction)
+	rnB := p.rB.PerpDotProduct(direction)
+	k := c.a.GetInverseMass() + c.b.GetInverseMass() +
+		rnA*rnA*c.a.GetInverseInertia() + rnB*rnB*c.b.GetInverseInertia()
+	if k == 0 {
+		return 0
+	}
+	return 1 / k
+}
+
+// relativeVelocity returns the velocity of b relative to a at the point
+func (c *contact) relativeVelocity(p *contactPoint) vector.Vector {
+	return c.b.GetVelocityAtPoint(p.position).Subtract(c.a.GetVelocityAtPoint(p.position))
+}
+
+// solve applies one iteration of impulses at each point.
+// Impulses are accumulated and clamped over iterations, so the bodies are never pulled together
+// and friction never exceeds the Coulomb limit.
+func (c *contact) solve() {
+	// friction first, as non-penetration is more important
+	tangent := c.normal.Perpendicular()
+	for _, p := range c.points {
+		vt := c.relativeVelocity(p).DotProduct(tangent)
+		maxFriction := c.friction * p.normalImpulse
+		tangentImpulse := clamp(p.tangentImpulse-vt*p.tangentMass, -maxFriction, maxFriction)
+		c.applyImpulse(p, tangent.Scale(tangentImpulse-p.tangentImpulse))
+		p.tangentImpulse = tangentImpulse
+	}
+
+	if len(c.points) == 2 && c.solveNormalBlock() {
+		return
+	}
+
+	for _, p := range c.points {
+		vn := c.relativeVelocity(p).DotProduct(c.normal)
+		normalImpulse := math.Max(p.normalImpulse+(p.bounce-vn)*p.normalMass, 0)
+		c.applyImpulse(p, c.normal.Scale(normalImpulse-p.normalImpulse))
+		p.normalImpulse = normalImpulse
+	}
+}
+
+// solveNormalBlock solves the normal impulses of a two point contact together.
+// Solving the points one after the other spins the bodies slightly each time,
+// which makes boxes resting on faces creep and stacks of them lean.
+// It reports false if the points are too close together to solve as a pair.
+func (c *contact) solveNormalBlock() bool {
+	p1, p2 := c.points[0], c.points[1]
+	invMassA, invMassB := c.a.GetInverseMass(), c.b.GetInverseMass()
+	invInertiaA, invInertiaB := c.a.GetInverseInertia(), c.b.GetInverseInertia()
+
+	rn1A := p1.rA.PerpDotProduct(c.normal)
+	rn1B := p1.rB.PerpDotProduct(c.normal)
+	rn2A := p2.rA.PerpDotProduct(c.normal)
+	rn2B := p2.rB.PerpDotProduct(c.normal)
+
+	k11 := invMassA + invMassB + invInertiaA*rn1A*rn1A + invInertiaB*rn1B*rn1B
+	k22 := invMassA + invMassB + invInertiaA*rn2A*rn2A + invInertiaB*rn2B*rn2B
+	k12 := invMassA + invMassB + invInertiaA*rn1A*rn2A + invInertiaB*rn1B*rn2B
+	det := k11*k22 - k12*k12
+	if k11*k11 >= maxBlockConditionNumber*det {
+		return false
+	}
+
+	// find impulses x that make the normal velocities vn = K x + b non-negative,
+	// with x non-negative and zero wherever vn is positive
+	a1, a2 := p1.normalImpulse, p2.normalImpulse
+	b1 := c.relativeVelocity(p1).DotProduct(c.normal) - p1.bounce - (k11*a1 + k12*a2)
+	b2 := c.relativeVelocity(p2).DotProduct(c.normal) - p2.bounce - (k12*a1 + k22*a2)
+
+	apply := func(x1, x2 float64) {
+		c.applyImpulse(p1, c.normal.Scale(x1-a1))
+		c.applyImpulse(p2, c.normal.Scale(x2-a2))
+		p1.normalImpulse, p2.normalImpulse = x1, x2
+	}
+
+	// both points pushing
+	x1 := (k12*b2 - k22*b1) / det
+	x2 := (k12*b1 - k11*b2) / det
+	if x1 >= 0 && x2 >= 0 {
+		apply(x1, x2)
+		return true
+	}
+
+	// only the first point pushing
+	x1 = -b1 / k11
+	if x1 >= 0 && k12*x1+b2 >= 0 {
+		apply(x1, 0)
+		return true
+	}
+
+	// only the second point pushing
+	x2 = -b2 / k22
+	if x2 >= 0 && k12*x2+b1 >= 0 {
+		apply(0, x2)
+		return true
+	}
+
+	// neither point pushing
+	if b1 >= 0 && b2 >= 0 {
+		apply(0, 0)
+	}
+	return true
+}
+
+// applyImpulse pushes b by the impulse and a by the opposite
+func (c *contact) applyImpulse(p *contactPoint, impulse vector.Vector) {
+	c.a.ApplyImpulseAtPoint(impulse.Scale(-1), p.position)
+	c.b.ApplyImpulseAtPoint(impulse, p.position)
+}
+
+// correctPosition pushes the bodies apart along the normal until they have separated by the percentage
+// of their overlap beyond the slop at the start of the step, counting how far they have been pushed already.
+// The push is split between the bodies by inverse mass, so static and kinematic bodies don't move.
+// It is repeated over every contact so that corrections spread through stacks of bodies,
+// without pushing any pair further than the percentage.
+func (c *contact) correctPosition(percent, slop float64) {
+	invMassA := c.a.GetInverseMass()
+	invMassB := c.b.GetInverseMass()
+	if invMassA+invMassB == 0 {
+		return
+	}
+
+	movedA := c.a.GetCentre().Subtract(c.centreA)
+	movedB := c.b.GetCentre().Subtract(c.centreB)
+	separated := movedB.Subtract(movedA).DotProduct(c.normal)
+	target := percent * math.Max(c.depth-slop, 0)
+	remaining := math.Max(target-separated, 0)
+	correction := c.normal.Scale(remaining / (invMassA + invMassB))
+	c.a.AdjustPosition(correction.Scale(-invMassA))
+	c.b.AdjustPosition(correction.Scale(invMassB))
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(v, max))
+}
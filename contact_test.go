@@ -0,0 +1,298 @@
+package ganymede
+
+import (
+	"ganymede/force"
+	"ganymede/object"
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestContactResponse(t *testing.T) {
+	headOn := func(restitution, massB float64) (object.Circle, object.Circle) {
+		w := NewWorld()
+		a := object.NewCircleObject(10, 1, vector.NewVector(0, 0))
+		b := object.NewCircleObject(10, massB, vector.NewVector(20, 0))
+		a.SetRestitution(restitution)
+		b.SetRestitution(restitution)
+		a.SetVelocity(vector.NewVector(10, 0))
+		w.AddBody(&a)
+		w.AddBody(&b)
+		w.Step(0.001)
+		return a, b
+	}
+
+	Convey("Should exchange velocities in an elastic collision of equal masses", t, func() {
+		a, b := headOn(1, 1)
+		So(a.GetVelocity().GetVals()[0], ShouldAlmostEqual, 0)
+		So(b.GetVelocity().GetVals()[0], ShouldAlmostEqual, 10)
+	})
+
+	Convey("Should move together after an inelastic collision", t, func() {
+		a, b := headOn(0, 1)
+		So(a.GetVelocity().GetVals()[0], ShouldAlmostEqual, 5)
+		So(b.GetVelocity().GetVals()[0], ShouldAlmostEqual, 5)
+	})
+
+	Convey("Should conserve momentum between different masses", t, func() {
+		a, b := headOn(1, 3)
+		momentum := a.GetVelocity().GetVals()[0]*1 + b.GetVelocity().GetVals()[0]*3
+		So(momentum, ShouldAlmostEqual, 10)
+		So(a.GetVelocity().GetVals()[0], ShouldAlmostEqual, -5)
+		So(b.GetVelocity().GetVals()[0], ShouldAlmostEqual, 5)
+	})
+
+	Convey("Should not pull separating bodies together", t, func() {
+		w := NewWorld()
+		a := object.NewCircleObject(10, 1, vector.NewVector(0, 0))
+		b := object.NewCircleObject(10, 1, vector.NewVector(20, 0))
+		a.SetVelocity(vector.NewVector(-10, 0))
+		w.AddBody(&a)
+		w.AddBody(&b)
+		w.Step(0.001)
+		So(a.GetVelocity().GetVals()[0], ShouldEqual, -10)
+		So(b.GetVelocity().GetVals()[0], ShouldEqual, 0)
+	})
+
+	Convey("Should bounce off static bodies without moving them", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(10, 1, vector.NewVector(0, 109))
+		floor := object.NewRectangleObject(100, 100, 0, vector.NewVector(-50, 0))
+		ball.SetRestitution(0.5)
+		ball.SetVelocity(vector.NewVector(0, -20))
+		w.AddBody(&ball)
+		w.AddBody(&floor)
+		w.Step(0.001)
+		So(ball.GetVelocity().GetVals()[1], ShouldAlmostEqual, 10)
+		So(floor.GetVelocity().GetVals()[1], ShouldEqual, 0)
+	})
+
+	Convey("Should slow a sliding body with friction", t, func() {
+		slide := func(friction float64) float64 {
+			w := NewWorld()
+			ball := object.NewCircleObject(10, 1, vector.NewVector(0, 110))
+			floor := object.NewRectangleObject(10000, 100, 0, vector.NewVector(-5000, 0))
+			ball.SetFriction(friction)
+			floor.SetFriction(friction)
+			ball.SetVelocity(vector.NewVector(100, 0))
+			w.AddBody(&ball)
+			w.AddBody(&floor)
+			w.AddForce(force.Gravity(vector.NewVector(0, -100)))
+			for i := 0; i < 60; i++ {
+				w.Step(1.0 / 60)
+			}
+			return ball.GetVelocity().GetVals()[0]
+		}
+
+		So(slide(0), ShouldAlmostEqual, 100)
+		So(slide(0.5), ShouldBeLessThan, 100)
+	})
+
+	Convey("Should spin a box hit off-centre", t, func() {
+		w := NewWorld()
+		crate := object.NewRectangleObject(20, 20, 1, vector.NewVector(0, 0))
+		ball := object.NewCircleObject(5, 1, vector.NewVector(-5, 18))
+		ball.SetFriction(0)
+		crate.SetFriction(0)
+		ball.SetVelocity(vector.NewVector(50, 0))
+		w.AddBody(&crate)
+		w.AddBody(&ball)
+		w.Step(0.001)
+		So(crate.GetVelocity().GetVals()[0], ShouldBeGreaterThan, 0)
+		So(crate.GetAngularVelocity(), ShouldBeLessThan, 0)
+	})
+}
+
+func TestTumbling(t *testing.T) {
+	Convey("Should tumble a tilted crate onto its face", t, func() {
+		w := NewWorld()
+		floor := object.NewRectangleObject(1000, 100, 0, vector.NewVector(-500, -100))
+		crate := object.NewRectangleObject(20, 20, 1, vector.NewVector(-10, 50))
+		crate.SetAngle(math.Pi / 6)
+		w.AddBody(&floor)
+		w.AddBody(&crate)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		spun := false
+		for i := 0; i < 300; i++ {
+			w.Step(1.0 / 60)
+			spun = spun || crate.GetAngularVelocity() != 0
+		}
+		So(spun, ShouldBeTrue)
+		So(crate.GetAngle(), ShouldAlmostEqual, 0, 0.05)
+		So(crate.GetCentre().GetVals()[1], ShouldAlmostEqual, 10, 0.5)
+		So(crate.GetAngularVelocity(), ShouldAlmostEqual, 0, 0.01)
+	})
+}
+
+func TestShapesResting(t *testing.T) {
+	Convey("Should rest a polygon dropped onto the floor", t, func() {
+		w := NewWorld()
+		floor := object.NewRectangleObject(1000, 100, 0, vector.NewVector(-500, -100))
+		hexagon := []vector.Vector{}
+		for i := 0; i < 6; i++ {
+			angle := float64(i) * math.Pi / 3
+			hexagon = append(hexagon, vector.NewVector(10*math.Cos(angle), 10*math.Sin(angle)))
+		}
+		p, err := object.NewPolygonObject(hexagon, 1, vector.NewVector(0, 30))
+		So(err, ShouldBeNil)
+		w.AddBody(&floor)
+		w.AddBody(&p)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		for i := 0; i < 300; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(p.GetCentre().GetVals()[1], ShouldAlmostEqual, 10*math.Sin(math.Pi/3), 0.5)
+		So(p.GetVelocity().Magnitude(), ShouldBeLessThan, 1)
+	})
+
+	Convey("Should rest a capsule dropped on its side onto the floor", t, func() {
+		w := NewWorld()
+		floor := object.NewRectangleObject(1000, 100, 0, vector.NewVector(-500, -100))
+		capsule := object.NewCapsuleObject(30, 5, 1, vector.NewVector(0, 30))
+		capsule.SetAngle(math.Pi/2 + 0.2)
+		w.AddBody(&floor)
+		w.AddBody(&capsule)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		for i := 0; i < 300; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(math.Abs(math.Sin(capsule.GetAngle())), ShouldAlmostEqual, 1, 0.01)
+		So(capsule.GetCentre().GetVals()[1], ShouldAlmostEqual, 5, 0.5)
+		So(capsule.GetVelocity().Magnitude(), ShouldBeLessThan, 1)
+	})
+
+	Convey("Should rest a compound body on all the parts it lands on", t, func() {
+		w := NewWorld()
+		floor := object.NewRectangleObject(1000, 100, 0, vector.NewVector(-500, -100))
+		left := object.NewCircleObject(5, 1, vector.NewVector(-20, 0))
+		bar := object.NewRectangleObject(30, 4, 0.5, vector.NewVector(-15, -2))
+		right := object.NewCircleObject(5, 1, vector.NewVector(20, 0))
+		dumbbell, err := object.NewCompoundObject([]object.Part{&left, &bar, &right}, vector.NewVector(0, 30))
+		So(err, ShouldBeNil)
+		w.AddBody(&floor)
+		w.AddBody(&dumbbell)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		for i := 0; i < 300; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(dumbbell.GetAngle(), ShouldAlmostEqual, 0, 0.01)
+		So(dumbbell.GetCentre().GetVals()[1], ShouldAlmostEqual, 5, 0.5)
+		So(dumbbell.GetVelocity().Magnitude(), ShouldBeLessThan, 1)
+	})
+
+	Convey("Should slide a box along a chain without snagging on its vertices", t, func() {
+		w := NewWorld()
+		vertices := []vector.Vector{}
+		for x := -100.0; x <= 1000; x += 25 {
+			vertices = append(vertices, vector.NewVector(x, 0))
+		}
+		ground, err := object.NewChainObject(vertices, false)
+		So(err, ShouldBeNil)
+		crate := object.NewRectangleObject(20, 20, 1, vector.NewVector(0, 0))
+		crate.SetFriction(0)
+		crate.SetVelocity(vector.NewVector(200, 0))
+		w.AddBody(&ground)
+		w.AddBody(&crate)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		for i := 0; i < 180; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(crate.GetVelocity().GetVals()[0], ShouldAlmostEqual, 200, 1)
+		So(crate.GetAngle(), ShouldAlmostEqual, 0, 0.01)
+		So(crate.GetPosition().GetVals()[1], ShouldAlmostEqual, 0, 0.5)
+	})
+}
+
+func TestPositionCorrection(t *testing.T) {
+	Convey("Should split the correction between bodies by inverse mass", t, func() {
+		w := NewWorld()
+		w.SetPositionCorrection(1, 0)
+		light := object.NewCircleObject(10, 1, vector.NewVector(0, 0))
+		heavy := object.NewCircleObject(10, 3, vector.NewVector(16, 0))
+		w.AddBody(&light)
+		w.AddBody(&heavy)
+		w.Step(0)
+		So(light.GetPosition().GetVals()[0], ShouldAlmostEqual, -3)
+		So(heavy.GetPosition().GetVals()[0], ShouldAlmostEqual, 17)
+	})
+
+	Convey("Should remove the percentage of the overlap beyond the slop in one step", t, func() {
+		w := NewWorld()
+		w.SetPositionCorrection(0.5, 0.1)
+		left := object.NewCircleObject(10, 1, vector.NewVector(0, 0))
+		right := object.NewCircleObject(10, 1, vector.NewVector(16, 0))
+		w.AddBody(&left)
+		w.AddBody(&right)
+		w.Step(0)
+		distance := right.GetPosition().Subtract(left.GetPosition()).Magnitude()
+		So(20-distance, ShouldAlmostEqual, 4-0.5*(4-0.1))
+	})
+
+	Convey("Should only move the dynamic body against a static one", t, func() {
+		w := NewWorld()
+		w.SetPositionCorrection(1, 0)
+		floor := object.NewRectangleObject(100, 10, 0, vector.NewVector(0, 0))
+		crate := object.NewRectangleObject(10, 10, 1, vector.NewVector(10, 6))
+		w.AddBody(&floor)
+		w.AddBody(&crate)
+		w.Step(0)
+		So(floor.GetPosition().GetVals()[1], ShouldEqual, 0)
+		So(crate.GetPosition().GetVals()[1], ShouldAlmostEqual, 10)
+	})
+
+	settle := func(stack []Body) {
+		w := NewWorld()
+		floor := object.NewRectangleObject(1000, 100, 0, vector.NewVector(-500, -100))
+		w.AddBody(&floor)
+		for _, b := range stack {
+			w.AddBody(b)
+		}
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+		for i := 0; i < 600; i++ {
+			w.Step(1.0 / 60)
+		}
+	}
+
+	Convey("Should keep a resting stack of boxes stable", t, func() {
+		stack := []object.Rectangle{}
+		for i := 0; i < 4; i++ {
+			stack = append(stack, object.NewRectangleObject(20, 20, 1, vector.NewVector(-10, float64(i)*20)))
+		}
+		bodies := []Body{}
+		for i := range stack {
+			bodies = append(bodies, &stack[i])
+		}
+		settle(bodies)
+
+		for i, box := range stack {
+			So(box.GetPosition().GetVals()[0], ShouldAlmostEqual, -10, 0.5)
+			So(box.GetPosition().GetVals()[1], ShouldAlmostEqual, float64(i)*20, 1)
+			So(box.GetVelocity().Magnitude(), ShouldBeLessThan, 1)
+		}
+	})
+
+	Convey("Should keep a resting stack of circles stable", t, func() {
+		stack := []object.Circle{}
+		for i := 0; i < 4; i++ {
+			stack = append(stack, object.NewCircleObject(10, 1, vector.NewVector(0, 10+float64(i)*20)))
+		}
+		bodies := []Body{}
+		for i := range stack {
+			bodies = append(bodies, &stack[i])
+		}
+		settle(bodies)
+
+		for i, ball := range stack {
+			So(ball.GetPosition().GetVals()[0], ShouldAlmostEqual, 0, 0.5)
+			So(ball.GetPosition().GetVals()[1], ShouldAlmostEqual, 10+float64(i)*20, 1)
+			So(ball.GetVelocity().Magnitude(), ShouldBeLessThan, 1)
+		}
+	})
+}
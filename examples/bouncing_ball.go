@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"ganymede"
+	"ganymede/broadphase"
 	"ganymede/force"
+	"ganymede/integrator"
 	"ganymede/object"
 	"ganymede/vector"
 	"image/color"
@@ -30,7 +33,7 @@ func main() {
 	canvasInstance := canvas.NewCanvas(&canvas.CanvasConfig{
 		Width:     canvasWidth,
 		Height:    canvasHeight,
-		FrameRate: 30,
+		FrameRate: frameRate,
 		Title:     "Bouncing Ball",
 	})
 
@@ -43,6 +46,9 @@ func main() {
 		ballColour,
 		object.NewCircleObject(20, 1, vector.NewVector(400, 400)),
 	}
+	ball.SetRestitution(0.7)
+	// the wind can blow the ball faster than the walls are thick
+	ball.SetBullet(true)
 
 	platform := rectangle{
 		platformColour,
@@ -60,43 +66,41 @@ func main() {
 		},
 	}
 
-	gravity := vector.NewVector(0, -1)
-	drag := vector.NewVector(0, 0.2)
-	windL := vector.NewVector(10, 0)
-	windR := vector.NewVector(-10, 0)
+	// accelerations are in pixels per second squared, and the ball's mass is 1
+	gravity := vector.NewVector(0, -900)
+	windL := vector.NewVector(9000, 0)
+	windR := vector.NewVector(-9000, 0)
+	wind := vector.NewVector(0, 0)
+
+	world := ganymede.NewWorld()
+	world.SetBroadPhase(broadphase.NewSpatialHash(100))
+	world.AddBody(&ball)
+	world.AddBody(&platform)
+	for i := range walls {
+		world.AddBody(&walls[i])
+	}
+	world.AddForce(force.Gravity(gravity))
+	world.AddForce(force.Drag(0.2))
+	world.AddForce(func(o object.Object, s integrator.State) vector.Vector {
+		return wind
+	})
 
 	canvasInstance.Draw(func(ctx *canvas.Context) {
 
-		sumAccel := gravity.Add(drag)
+		wind = vector.NewVector(0, 0)
 
 		if ctx.IsKeyPressed(pixelgl.KeyR) {
 			fmt.Println("Wind right")
-			sumAccel = sumAccel.Add(windL)
+			wind = windL
 		} else if ctx.IsKeyPressed(pixelgl.KeyL) {
 			fmt.Println("Wind left")
-			sumAccel = sumAccel.Add(windR)
-		}
-
-		if collided, collisionNormal := object.DetectCollision(&ball, &platform); collided {
-			dimensionV := vector.NewVector(ball.Radius, ball.Radius)
-			ball.CollisionOverlapCorrection(collisionNormal, dimensionV)
-
-			springForce := force.Spring(&ball, collisionNormal.AsUnitVector())
-			sumAccel = sumAccel.Add(springForce)
+			wind = windR
 		}
 
-		for _, wall := range walls {
-			if collided, collisionNormal := object.DetectCollision(&ball, &wall); collided {
-				dimensionV := vector.NewVector(ball.Radius, ball.Radius)
-				ball.CollisionOverlapCorrection(collisionNormal, dimensionV)
-
-				springForce := force.Spring(&ball, collisionNormal.AsUnitVector())
-				sumAccel = sumAccel.Add(springForce)
-			}
+		if err := world.Step(1.0 / frameRate); err != nil {
+			fmt.Println(err)
 		}
 
-		ball.ApplyAcceleration(sumAccel)
-
 		ctx.Clear()
 		background.Draw(ctx)
 		ball.Draw(ctx)
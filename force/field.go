@@ -0,0 +1,49 @@
+package force
+
+import (
+	"ganymede/integrator"
+	"ganymede/object"
+	"ganymede/vector"
+)
+
+// Field is a global force acting on every object in a world.
+// It returns the force the field exerts on the object when it is in the state s.
+// Integrators may evaluate a field at several intermediate states per step.
+type Field func(o object.Object, s integrator.State) vector.Vector
+
+// Constant creates a field that exerts the same force on every object,
+// for example a steady wind. Lighter objects are accelerated more.
+func Constant(force vector.Vector) Field {
+	return func(o object.Object, s integrator.State) vector.Vector {
+		return force
+	}
+}
+
+// Gravity creates a field that gives every object the same acceleration,
+// whatever its mass.
+func Gravity(acceleration vector.Vector) Field {
+	return func(o object.Object, s integrator.State) vector.Vector {
+		return acceleration.Scale(o.GetMass())
+	}
+}
+
+// Drag creates a field that opposes the velocity of every object
+// with a force proportional to its speed.
+func Drag(coefficient float64) Field {
+	return func(o object.Object, s integrator.State) vector.Vector {
+		return s.Velocity.Scale(-coefficient)
+	}
+}
+
+// Attractor creates a field that pulls every object towards the centre with an
+// acceleration of strength / distance², for example to hold bodies in orbit.
+func Attractor(centre vector.Vector, strength float64) Field {
+	return func(o object.Object, s integrator.State) vector.Vector {
+		toCentre := centre.Subtract(s.Position)
+		distance := toCentre.Magnitude()
+		if distance == 0 {
+			return toCentre
+		}
+		return toCentre.Scale(o.GetMass() * strength / (distance * distance * distance))
+	}
+}
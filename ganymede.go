@@ -1 +1,3 @@
+// Package ganymede is a 2D physics engine. A World holds bodies and global
+// forces, and advances them with Step.
 package ganymede
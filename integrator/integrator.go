@@ -0,0 +1,96 @@
+package integrator
+
+import (
+	"ganymede/vector"
+)
+
+// State is the position and velocity of an object at an instant
+type State struct {
+	Position vector.Vector
+	Velocity vector.Vector
+}
+
+// AccelerationFunc returns the acceleration of an object in the given state.
+// Integrators may evaluate it several times per step at intermediate states.
+type AccelerationFunc func(s State) vector.Vector
+
+// Integrator advances a state through the timestep dt
+type Integrator interface {
+	Integrate(s State, acceleration AccelerationFunc, dt float64) State
+}
+
+// Euler is the explicit Euler method.
+// Position is advanced with the velocity from the start of the step.
+// It is cheap but gains energy steadily, so orbits spiral outwards.
+type Euler struct{}
+
+// Integrate implements Integrator
+func (Euler) Integrate(s State, acceleration AccelerationFunc, dt float64) State {
+	a := acceleration(s)
+	return State{
+		Position: s.Position.Add(s.Velocity.Scale(dt)),
+		Velocity: s.Velocity.Add(a.Scale(dt)),
+	}
+}
+
+// SemiImplicitEuler is the symplectic Euler method.
+// Velocity is advanced first and the new velocity moves the position.
+// Energy oscillates around the true value instead of drifting.
+type SemiImplicitEuler struct{}
+
+// Integrate implements Integrator
+func (SemiImplicitEuler) Integrate(s State, acceleration AccelerationFunc, dt float64) State {
+	a := acceleration(s)
+	v := s.Velocity.Add(a.Scale(dt))
+	return State{
+		Position: s.Position.Add(v.Scale(dt)),
+		Velocity: v,
+	}
+}
+
+// VelocityVerlet is second order and symplectic.
+// The velocity is advanced with the average of the accelerations at the start and end of the step.
+type VelocityVerlet struct{}
+
+// Integrate implements Integrator
+func (VelocityVerlet) Integrate(s State, acceleration AccelerationFunc, dt float64) State {
+	a0 := acceleration(s)
+	p := s.Position.Add(s.Velocity.Scale(dt)).Add(a0.Scale(0.5 * dt * dt))
+	a1 := acceleration(State{p, s.Velocity.Add(a0.Scale(dt))})
+	return State{
+		Position: p,
+		Velocity: s.Velocity.Add(a0.Add(a1).Scale(0.5 * dt)),
+	}
+}
+
+// RK4 is the classic fourth order Runge-Kutta method.
+// It evaluates the acceleration four times per step and is very accurate over short runs,
+// though it slowly loses energy over long ones.
+type RK4 struct{}
+
+// Integrate implements Integrator
+func (RK4) Integrate(s State, acceleration AccelerationFunc, dt float64) State {
+	k1v := acceleration(s)
+	k1p := s.Velocity
+
+	s2 := State{s.Position.Add(k1p.Scale(dt / 2)), s.Velocity.Add(k1v.Scale(dt / 2))}
+	k2v := acceleration(s2)
+	k2p := s2.Velocity
+
+	s3 := State{s.Position.Add(k2p.Scale(dt / 2)), s.Velocity.Add(k2v.Scale(dt / 2))}
+	k3v := acceleration(s3)
+	k3p := s3.Velocity
+
+	s4 := State{s.Position.Add(k3p.Scale(dt)), s.Velocity.Add(k3v.Scale(dt))}
+	k4v := acceleration(s4)
+	k4p := s4.Velocity
+
+	return State{
+		Position: s.Position.Add(weightedSum(k1p, k2p, k3p, k4p).Scale(dt / 6)),
+		Velocity: s.Velocity.Add(weightedSum(k1v, k2v, k3v, k4v).Scale(dt / 6)),
+	}
+}
+
+func weightedSum(k1, k2, k3, k4 vector.Vector) vector.Vector {
+	return k1.Add(k2.Scale(2)).Add(k3.Scale(2)).Add(k4)
+}
@@ -0,0 +1,109 @@
+package integrator
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	driftSteps = 10000
+	driftDt    = 0.01
+)
+
+// spring is a unit mass on a unit stiffness spring anchored at the origin
+func spring(s State) vector.Vector {
+	return s.Position.Scale(-1)
+}
+
+func springEnergy(s State) float64 {
+	return 0.5*s.Velocity.DotProduct(s.Velocity) + 0.5*s.Position.DotProduct(s.Position)
+}
+
+// orbit is a unit mass attracted to a unit mass at the origin
+func orbit(s State) vector.Vector {
+	r := s.Position.Magnitude()
+	return s.Position.Scale(-1 / (r * r * r))
+}
+
+func orbitEnergy(s State) float64 {
+	return 0.5*s.Velocity.DotProduct(s.Velocity) - 1/s.Position.Magnitude()
+}
+
+// energyDrift runs the integrator over a long simulation and returns the
+// largest relative change in energy seen, and the final state
+func energyDrift(in Integrator, s State, acceleration AccelerationFunc, energy func(State) float64) (float64, State) {
+	e0 := energy(s)
+	maxDrift := 0.0
+	for i := 0; i < driftSteps; i++ {
+		s = in.Integrate(s, acceleration, driftDt)
+		drift := math.Abs(energy(s)-e0) / math.Abs(e0)
+		maxDrift = math.Max(maxDrift, drift)
+	}
+	return maxDrift, s
+}
+
+func springStart() State {
+	return State{vector.NewVector(1, 0), vector.NewVector(0, 0)}
+}
+
+func circularOrbitStart() State {
+	return State{vector.NewVector(1, 0), vector.NewVector(0, 1)}
+}
+
+func TestEnergyDrift(t *testing.T) {
+	Convey("Explicit Euler should gain energy", t, func() {
+		drift, s := energyDrift(Euler{}, springStart(), spring, springEnergy)
+		So(drift, ShouldBeGreaterThan, 1)
+		So(springEnergy(s), ShouldBeGreaterThan, springEnergy(springStart()))
+
+		drift, s = energyDrift(Euler{}, circularOrbitStart(), orbit, orbitEnergy)
+		So(drift, ShouldBeGreaterThan, 0.1)
+		So(s.Position.Magnitude(), ShouldBeGreaterThan, 1.5)
+	})
+
+	Convey("Semi-implicit Euler should keep energy bounded", t, func() {
+		drift, _ := energyDrift(SemiImplicitEuler{}, springStart(), spring, springEnergy)
+		So(drift, ShouldBeLessThan, 1e-2)
+
+		drift, s := energyDrift(SemiImplicitEuler{}, circularOrbitStart(), orbit, orbitEnergy)
+		So(drift, ShouldBeLessThan, 1e-3)
+		So(s.Position.Magnitude(), ShouldAlmostEqual, 1, 1e-2)
+	})
+
+	Convey("Velocity Verlet should keep energy bounded more tightly", t, func() {
+		drift, _ := energyDrift(VelocityVerlet{}, springStart(), spring, springEnergy)
+		So(drift, ShouldBeLessThan, 1e-4)
+
+		drift, s := energyDrift(VelocityVerlet{}, circularOrbitStart(), orbit, orbitEnergy)
+		So(drift, ShouldBeLessThan, 1e-7)
+		So(s.Position.Magnitude(), ShouldAlmostEqual, 1, 1e-4)
+	})
+
+	Convey("RK4 should lose a tiny amount of energy", t, func() {
+		drift, s := energyDrift(RK4{}, springStart(), spring, springEnergy)
+		So(drift, ShouldBeLessThan, 1e-8)
+		So(springEnergy(s), ShouldBeLessThan, springEnergy(springStart()))
+
+		drift, s = energyDrift(RK4{}, circularOrbitStart(), orbit, orbitEnergy)
+		So(drift, ShouldBeLessThan, 1e-8)
+		So(s.Position.Magnitude(), ShouldAlmostEqual, 1, 1e-6)
+	})
+}
+
+func TestConstantAcceleration(t *testing.T) {
+	Convey("Second order schemes should be exact under constant acceleration", t, func() {
+		gravity := func(s State) vector.Vector { return vector.NewVector(0, -10) }
+		for _, in := range []Integrator{VelocityVerlet{}, RK4{}} {
+			s := State{vector.NewVector(0, 0), vector.NewVector(5, 20)}
+			for i := 0; i < 20; i++ {
+				s = in.Integrate(s, gravity, 0.1)
+			}
+			So(s.Position.GetVals()[0], ShouldAlmostEqual, 10, 1e-9)
+			So(s.Position.GetVals()[1], ShouldAlmostEqual, 20, 1e-9)
+			So(s.Velocity.GetVals()[1], ShouldAlmostEqual, 0, 1e-9)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+)
+
+// AABB is an axis-aligned bounding box
+type AABB struct {
+	Min, Max vector.Vector
+}
+
+// Bounded is implemented by shapes that can report the box they fit inside
+type Bounded interface {
+	GetAABB() AABB
+}
+
+// NewAABB returns the smallest box containing the points
+func NewAABB(points ...vector.Vector) AABB {
+	return aabbOfVertices(points)
+}
+
+// Overlaps returns true if the boxes overlap or touch
+func (a AABB) Overlaps(b AABB) bool {
+	min1, max1 := a.Min.GetVals(), a.Max.GetVals()
+	min2, max2 := b.Min.GetVals(), b.Max.GetVals()
+	for i := range min1 {
+		if max1[i] < min2[i] || max2[i] < min1[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains returns true if the box lies entirely inside this one
+func (a AABB) Contains(b AABB) bool {
+	min1, max1 := a.Min.GetVals(), a.Max.GetVals()
+	min2, max2 := b.Min.GetVals(), b.Max.GetVals()
+	for i := range min1 {
+		if min2[i] < min1[i] || max1[i] < max2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsRay returns how far along the ray it enters the box, if it does within maxDistance.
+// The direction must be a unit vector. A ray starting inside the box enters it at 0.
+func (a AABB) IntersectsRay(origin, direction vector.Vector, maxDistance float64) (float64, bool) {
+	min, max := a.Min.GetVals(), a.Max.GetVals()
+	o, d := origin.GetVals(), direction.GetVals()
+	enter, exit := 0.0, maxDistance
+	for i := range min {
+		if d[i] == 0 {
+			// parallel to this pair of sides, so the ray must already be between them
+			if o[i] < min[i] || max[i] < o[i] {
+				return 0, false
+			}
+			continue
+		}
+		t1, t2 := (min[i]-o[i])/d[i], (max[i]-o[i])/d[i]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		enter, exit = math.Max(enter, t1), math.Min(exit, t2)
+		if enter > exit {
+			return 0, false
+		}
+	}
+	return enter, true
+}
+
+// Union returns the smallest box containing both boxes
+func (a AABB) Union(b AABB) AABB {
+	return aabbOfVertices([]vector.Vector{a.Min, a.Max, b.Min, b.Max})
+}
+
+// Expand returns the box grown by the margin on every side
+func (a AABB) Expand(margin float64) AABB {
+	vals := make([]float64, len(a.Min.GetVals()))
+	for i := range vals {
+		vals[i] = margin
+	}
+	m := vector.NewVector(vals...)
+	return AABB{a.Min.Subtract(m), a.Max.Add(m)}
+}
+
+// aabbOfVertices returns the smallest box containing the points
+func aabbOfVertices(vertices []vector.Vector) AABB {
+	min := append([]float64{}, vertices[0].GetVals()...)
+	max := append([]float64{}, vertices[0].GetVals()...)
+	for _, v := range vertices[1:] {
+		for i, val := range v.GetVals() {
+			min[i] = math.Min(min[i], val)
+			max[i] = math.Max(max[i], val)
+		}
+	}
+	return AABB{vector.NewVector(min...), vector.NewVector(max...)}
+}
+
+// GetAABB returns the box around the circle. Implements Bounded
+func (c Circle) GetAABB() AABB {
+	return AABB{c.position, c.position}.Expand(c.Radius)
+}
+
+// GetAABB returns the box around the rectangle after rotation. Implements Bounded
+func (r Rectangle) GetAABB() AABB {
+	return aabbOfVertices(r.GetCorners())
+}
+
+// GetAABB returns the box around the polygon. Implements Bounded
+func (p Polygon) GetAABB() AABB {
+	return aabbOfVertices(p.GetVertices())
+}
+
+// GetAABB returns the box around the capsule. Implements Bounded
+func (c Capsule) GetAABB() AABB {
+	start, end := c.GetEndpoints()
+	return aabbOfVertices([]vector.Vector{start, end}).Expand(c.Radius)
+}
+
+// GetAABB returns the box around the segment. Implements Bounded
+func (s Segment) GetAABB() AABB {
+	start, end := s.GetEndpoints()
+	return aabbOfVertices([]vector.Vector{start, end})
+}
+
+// GetAABB returns the box around the chain. Implements Bounded
+func (c Chain) GetAABB() AABB {
+	return aabbOfVertices(c.GetVertices())
+}
+
+// GetAABB returns the box around all the parts of the compound. Implements Bounded
+func (c Compound) GetAABB() AABB {
+	parts := c.GetParts()
+	box := parts[0].GetAABB()
+	for _, p := range parts[1:] {
+		box = box.Union(p.GetAABB())
+	}
+	return box
+}
@@ -0,0 +1,49 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAABB(t *testing.T) {
+	box := AABB{vector.NewVector(0, 0), vector.NewVector(10, 10)}
+
+	Convey("Should fit the box around a rotated rectangle", t, func() {
+		r := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		r.SetAngle(math.Pi / 4)
+		b := r.GetAABB()
+		So(b.Min.GetVals()[0], ShouldAlmostEqual, 5-5*math.Sqrt2)
+		So(b.Max.GetVals()[1], ShouldAlmostEqual, 5+5*math.Sqrt2)
+	})
+
+	Convey("Should fit the box around a capsule's rounded ends", t, func() {
+		c := NewCapsuleObject(10, 2, 1, vector.NewVector(0, 0))
+		So(c.GetAABB().Min.GetVals(), ShouldResemble, []float64{-2, -7})
+		So(c.GetAABB().Max.GetVals(), ShouldResemble, []float64{2, 7})
+	})
+
+	Convey("Should only contain boxes entirely inside it", t, func() {
+		So(box.Contains(AABB{vector.NewVector(1, 1), vector.NewVector(10, 9)}), ShouldBeTrue)
+		So(box.Contains(AABB{vector.NewVector(1, 1), vector.NewVector(11, 9)}), ShouldBeFalse)
+	})
+
+	Convey("Should find where a ray enters the box", t, func() {
+		d, ok := box.IntersectsRay(vector.NewVector(-5, 5), vector.NewVector(1, 0), 100)
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, 5)
+
+		d, ok = box.IntersectsRay(vector.NewVector(5, 5), vector.NewVector(0, -1), 100)
+		So(ok, ShouldBeTrue)
+		So(d, ShouldEqual, 0)
+
+		_, ok = box.IntersectsRay(vector.NewVector(-5, 5), vector.NewVector(1, 0), 4)
+		So(ok, ShouldBeFalse)
+		_, ok = box.IntersectsRay(vector.NewVector(-5, 15), vector.NewVector(1, 0), 100)
+		So(ok, ShouldBeFalse)
+		_, ok = box.IntersectsRay(vector.NewVector(-5, 5), vector.NewVector(-1, 0), 100)
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,28 @@
+package object
+
+// BodyKind decides how an object takes part in the simulation
+type BodyKind int
+
+const (
+	// Dynamic bodies are fully simulated. They are moved by forces, impulses and contacts.
+	Dynamic BodyKind = iota
+	// Static bodies never move, like the ground or walls of a level.
+	Static
+	// Kinematic bodies are moved only by the velocity they are given.
+	// They ignore forces and contacts, but push dynamic bodies out of their way,
+	// like moving platforms.
+	Kinematic
+)
+
+func (k BodyKind) String() string {
+	switch k {
+	case Dynamic:
+		return "dynamic"
+	case Static:
+		return "static"
+	case Kinematic:
+		return "kinematic"
+	default:
+		return "unknown"
+	}
+}
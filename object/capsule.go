@@ -0,0 +1,65 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+)
+
+// NewCapsuleObject creates a new capsule: a segment of the length, rounded by the radius.
+// The position is the centre of the segment, which is upright before the capsule is rotated,
+// so the capsule is length + 2 * radius tall.
+func NewCapsuleObject(length float64, radius float64, mass float64, position vector.Vector) Capsule {
+	c := Capsule{
+		length,
+		radius,
+		NewGenericObject(mass, position, CollisionCapsule),
+	}
+
+	// the capsule is a rectangle between two half circles
+	rectangleArea := 2 * radius * length
+	circleArea := math.Pi * radius * radius
+	rectangleMass := mass * rectangleArea / (rectangleArea + circleArea)
+	circleMass := mass - rectangleMass
+
+	// each half circle's centroid is 4r/3π beyond the end of the segment
+	halfCircleOffset := 4 * radius / (3 * math.Pi)
+	c.SetInertia(rectangleMass*(4*radius*radius+length*length)/12 +
+		circleMass*(radius*radius/2+length*length/4+length*halfCircleOffset))
+	return c
+}
+
+// Capsule is an object with physical implementation for a 2D capsule
+type Capsule struct {
+	Length float64
+	Radius float64
+	GenericObject
+}
+
+// GetRadius returns the radius of the rounded ends
+func (c Capsule) GetRadius() float64 {
+	return c.Radius
+}
+
+// GetEndpoints returns the ends of the segment in world space after rotation.
+// Implements CapsuleCollider
+func (c Capsule) GetEndpoints() (vector.Vector, vector.Vector) {
+	half := rotate(vector.NewVector(0, c.Length/2), c.angle)
+	return c.position.Subtract(half), c.position.Add(half)
+}
+
+// Support returns the point on the capsule furthest in the direction. Implements Convex
+func (c Capsule) Support(direction vector.Vector) vector.Vector {
+	start, end := c.GetEndpoints()
+	return supportOfVertices([]vector.Vector{start, end}, direction).Add(direction.Normalize().Scale(c.Radius))
+}
+
+// roundedSegmentAndCircle tests the circle against the nearest point of a segment rounded by the radius
+func roundedSegmentAndCircle(start, end vector.Vector, radius float64, c CircleCollider) (bool, Manifold) {
+	nearest := nearestPointOnSegment(c.GetPosition(), start, end)
+	return circleAndCircle(circleShape{nearest, radius}, c)
+}
+
+// roundedSegmentAndPolygon tests a segment rounded by a radius as a polygon of two vertices
+func roundedSegmentAndPolygon(start, end vector.Vector, radius float64, vertices []vector.Vector, polygonRadius float64) (bool, Manifold) {
+	return satRoundedPolygons([]vector.Vector{start, end}, radius, vertices, polygonRadius)
+}
@@ -0,0 +1,108 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCapsule(t *testing.T) {
+	Convey("Should have the inertia of a circle when it has no length", t, func() {
+		c := NewCapsuleObject(0, 2, 3, vector.NewVector(0, 0))
+		So(c.GetInertia(), ShouldAlmostEqual, 3*2*2/2.0)
+	})
+
+	Convey("Should have the inertia of a rod when it is very thin", t, func() {
+		c := NewCapsuleObject(10, 1e-9, 3, vector.NewVector(0, 0))
+		So(c.GetInertia(), ShouldAlmostEqual, 3*10*10/12.0, 1e-6)
+	})
+
+	Convey("Should rotate its segment about its centre", t, func() {
+		c := NewCapsuleObject(10, 2, 1, vector.NewVector(1, 1))
+		start, end := c.GetEndpoints()
+		So(start.GetVals()[1], ShouldAlmostEqual, -4)
+		So(end.GetVals()[1], ShouldAlmostEqual, 6)
+
+		c.SetAngle(math.Pi / 2)
+		start, end = c.GetEndpoints()
+		So(start.GetVals()[0], ShouldAlmostEqual, 6)
+		So(start.GetVals()[1], ShouldAlmostEqual, 1)
+		So(end.GetVals()[0], ShouldAlmostEqual, -4)
+	})
+}
+
+func TestCapsuleCollisions(t *testing.T) {
+	Convey("Should collide a circle with the side of a capsule", t, func() {
+		c := NewCapsuleObject(10, 2, 1, vector.NewVector(0, 0))
+		ball := NewCircleObject(1, 1, vector.NewVector(2.5, 3))
+
+		collided, m, err := DetectCollision(&c, &ball)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.5)
+
+		collided, m, _ = DetectCollision(&ball, &c)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+	})
+
+	Convey("Should rest a capsule lying on a rectangle on two points", t, func() {
+		floor := NewRectangleObject(100, 10, 0, vector.NewVector(-50, -10))
+		c := NewCapsuleObject(10, 2, 1, vector.NewVector(0, 1.9))
+		c.SetAngle(math.Pi / 2)
+
+		collided, m, _ := DetectCollision(&floor, &c)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 0)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.1)
+		So(len(m.Points), ShouldEqual, 2)
+		for _, p := range m.Points {
+			So(p.GetVals()[1], ShouldAlmostEqual, -0.05)
+		}
+	})
+
+	Convey("Should collide the rounded end of a capsule with a corner", t, func() {
+		box := NewRectangleObject(10, 10, 0, vector.NewVector(0, 0))
+
+		// the faces of the box overlap the capsule's bounds, but the rounded end misses the corner
+		c := NewCapsuleObject(10, 2, 1, vector.NewVector(11.5, 16.5))
+		collided, _, _ := DetectCollision(&c, &box)
+		So(collided, ShouldBeFalse)
+
+		c = NewCapsuleObject(10, 2, 1, vector.NewVector(11.2, 16.2))
+		collided, m, _ := DetectCollision(&c, &box)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, -math.Sqrt2/2)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -math.Sqrt2/2)
+		So(m.Depth, ShouldAlmostEqual, 2-1.2*math.Sqrt2)
+	})
+
+	Convey("Should collide capsules side by side", t, func() {
+		c1 := NewCapsuleObject(10, 2, 1, vector.NewVector(0, 0))
+		c2 := NewCapsuleObject(10, 2, 1, vector.NewVector(3.5, 2))
+
+		collided, m, _ := DetectCollision(&c1, &c2)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.5)
+		So(len(m.Points), ShouldEqual, 2)
+
+		c2 = NewCapsuleObject(10, 2, 1, vector.NewVector(4.5, 2))
+		collided, _, _ = DetectCollision(&c1, &c2)
+		So(collided, ShouldBeFalse)
+	})
+
+	Convey("Should collide crossed capsules", t, func() {
+		c1 := NewCapsuleObject(10, 1, 1, vector.NewVector(0, 0))
+		c2 := NewCapsuleObject(10, 1, 1, vector.NewVector(0, 0))
+		c2.SetAngle(math.Pi / 2)
+
+		collided, m, _ := DetectCollision(&c1, &c2)
+		So(collided, ShouldBeTrue)
+		So(m.Depth, ShouldBeGreaterThan, 0)
+	})
+}
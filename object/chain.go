@@ -0,0 +1,168 @@
+package object
+
+import (
+	"errors"
+	"ganymede/vector"
+	"math"
+)
+
+// ErrZeroLengthEdge is returned when consecutive vertices of a chain are the same point
+var ErrZeroLengthEdge = errors.New("chain has an edge of zero length")
+
+// faceContactTolerance is how closely a contact normal must match an edge's normal to be a contact with its face
+const faceContactTolerance = 1e-3
+
+// NewChainObject creates a static polyline through the vertices, for outlining terrain.
+// The vertices are in world space. A closed chain joins its last vertex back to its first.
+// The position is the first vertex, which the chain rotates about.
+func NewChainObject(vertices []vector.Vector, closed bool) (Chain, error) {
+	if len(vertices) < 2 || closed && len(vertices) < 3 {
+		return Chain{}, ErrTooFewVertices
+	}
+
+	position := vertices[0]
+	local := make([]vector.Vector, len(vertices))
+	for i, v := range vertices {
+		local[i] = v.Subtract(position)
+	}
+	for i := 0; i < chainEdgeCount(local, closed); i++ {
+		if local[i].Subtract(local[(i+1)%len(local)]).Magnitude() == 0 {
+			return Chain{}, ErrZeroLengthEdge
+		}
+	}
+	return Chain{local, closed, NewGenericObject(0, position, CollisionChain)}, nil
+}
+
+// Chain is an object with physical implementation for a 2D polyline of segments.
+// Shapes slide smoothly over the vertices shared by its segments.
+type Chain struct {
+	vertices []vector.Vector // relative to the position
+	closed   bool
+	GenericObject
+}
+
+// GetVertices returns the vertices of the chain in world space after rotation
+func (c Chain) GetVertices() []vector.Vector {
+	world := make([]vector.Vector, len(c.vertices))
+	for i, v := range c.vertices {
+		world[i] = c.position.Add(rotate(v, c.angle))
+	}
+	return world
+}
+
+// IsClosed returns true if the last vertex of the chain joins its first
+func (c Chain) IsClosed() bool {
+	return c.closed
+}
+
+func chainEdgeCount(vertices []vector.Vector, closed bool) int {
+	if closed {
+		return len(vertices)
+	}
+	return len(vertices) - 1
+}
+
+// chainAnd tests each edge of the chain as a segment and merges the contacts into one manifold,
+// whose sub-shape is the index of the deepest edge.
+// Where a shape slides across a vertex shared by two edges, it can overlap the end of the next edge
+// before it reaches it, which would snag the shape on the vertex. Contacts like these are dropped,
+// as the edges' faces already hold the shape up.
+func chainAnd(chain ChainCollider, o Collider) (bool, Manifold) {
+	vertices := chain.GetVertices()
+	closed := chain.IsClosed()
+
+	manifolds := []Manifold{}
+	deepest := -1
+	for i := 0; i < chainEdgeCount(vertices, closed); i++ {
+		edge := segmentShape{vertices[i], vertices[(i+1)%len(vertices)]}
+		collided, m, err := DetectCollision(edge, o)
+		if err != nil || !collided || isInternalVertexContact(vertices, closed, i, m) {
+			continue
+		}
+
+		m.SubShapeA = i
+		manifolds = append(manifolds, m)
+		if deepest < 0 || m.Depth > manifolds[deepest].Depth {
+			deepest = len(manifolds) - 1
+		}
+	}
+	if deepest < 0 {
+		return false, Manifold{}
+	}
+
+	// keep the contacts that agree with the deepest, and the two points furthest apart among them
+	merged := Manifold{Normal: manifolds[deepest].Normal, SubShapeA: manifolds[deepest].SubShapeA}
+	tangent := merged.Normal.Perpendicular()
+	var first, last vector.Vector
+	minAlong, maxAlong := math.Inf(1), math.Inf(-1)
+	for _, m := range manifolds {
+		if m.Normal.DotProduct(merged.Normal) < 1-faceContactTolerance {
+			continue
+		}
+		merged.Depth = math.Max(merged.Depth, m.Depth)
+		for _, p := range m.Points {
+			along := p.DotProduct(tangent)
+			if along < minAlong {
+				first, minAlong = p, along
+			}
+			if along > maxAlong {
+				last, maxAlong = p, along
+			}
+		}
+	}
+	merged.Points = []vector.Vector{first}
+	if maxAlong-minAlong > faceContactTolerance {
+		merged.Points = append(merged.Points, last)
+	}
+	return true, merged
+}
+
+// isInternalVertexContact reports whether a contact with the end of an edge should be left to the neighbouring edge.
+// A contact normal that isn't the edge's face normal came from one of its vertices. If the chain continues
+// past that vertex, the contact is only real when the chain turns away from the shape there,
+// and the normal points out between the faces of the two edges.
+func isInternalVertexContact(vertices []vector.Vector, closed bool, i int, m Manifold) bool {
+	n := len(vertices)
+	start, end := vertices[i], vertices[(i+1)%n]
+
+	// the face of the edge on the shape's side
+	face := edgeNormal(start, end)
+	side := 1.0
+	if face.DotProduct(m.Normal) < 0 {
+		face, side = face.Scale(-1), -1
+	}
+	if face.DotProduct(m.Normal) >= 1-faceContactTolerance {
+		return false
+	}
+
+	// find which end of the edge the contact is at, and the next vertex past it along the chain
+	contact := zeroVector(start)
+	for _, p := range m.Points {
+		contact = contact.Add(p.Scale(1 / float64(len(m.Points))))
+	}
+	along := end.Subtract(start)
+	var vertex, neighbour, neighbourFace vector.Vector
+	if contact.Subtract(start).DotProduct(along) < along.DotProduct(along)/2 {
+		if !closed && i == 0 {
+			return false
+		}
+		vertex, neighbour = start, vertices[(i-1+n)%n]
+		neighbourFace = edgeNormal(neighbour, vertex).Scale(side)
+	} else {
+		if !closed && i == n-2 {
+			return false
+		}
+		vertex, neighbour = end, vertices[(i+2)%n]
+		neighbourFace = edgeNormal(vertex, neighbour).Scale(side)
+	}
+
+	// where the chain is flat or turns towards the shape, the faces cover every direction the vertex could
+	if neighbour.Subtract(vertex).DotProduct(face) >= 0 {
+		return true
+	}
+
+	// where it turns away, the vertex only owns the normals between the two faces
+	turn := face.PerpDotProduct(neighbourFace)
+	between := face.PerpDotProduct(m.Normal)*turn >= 0 && m.Normal.PerpDotProduct(neighbourFace)*turn >= 0
+	return !between
+}
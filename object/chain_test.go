@@ -0,0 +1,133 @@
+package object
+
+import (
+	"errors"
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSegment(t *testing.T) {
+	Convey("Should be static", t, func() {
+		s := NewSegmentObject(vector.NewVector(0, 0), vector.NewVector(10, 0))
+		So(s.GetKind(), ShouldEqual, Static)
+	})
+
+	Convey("Should rotate about its midpoint", t, func() {
+		s := NewSegmentObject(vector.NewVector(0, 0), vector.NewVector(10, 0))
+		s.SetAngle(math.Pi / 2)
+		start, end := s.GetEndpoints()
+		So(start.GetVals()[0], ShouldAlmostEqual, 5)
+		So(start.GetVals()[1], ShouldAlmostEqual, -5)
+		So(end.GetVals()[1], ShouldAlmostEqual, 5)
+	})
+
+	Convey("Should collide with circles and boxes", t, func() {
+		s := NewSegmentObject(vector.NewVector(-10, 0), vector.NewVector(10, 0))
+		ball := NewCircleObject(1, 1, vector.NewVector(0, 0.5))
+		collided, m, err := DetectCollision(&s, &ball)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.5)
+
+		box := NewRectangleObject(4, 4, 1, vector.NewVector(-2, -0.5))
+		collided, m, err = DetectCollision(&box, &s)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -1)
+		So(m.Depth, ShouldAlmostEqual, 0.5)
+		So(len(m.Points), ShouldEqual, 2)
+	})
+}
+
+func TestChain(t *testing.T) {
+	flat := []vector.Vector{
+		vector.NewVector(-50, 0),
+		vector.NewVector(0, 0),
+		vector.NewVector(50, 0),
+	}
+
+	Convey("Should reject chains without edges", t, func() {
+		_, err := NewChainObject(flat[:1], false)
+		So(err, ShouldEqual, ErrTooFewVertices)
+		_, err = NewChainObject(flat[:2], true)
+		So(err, ShouldEqual, ErrTooFewVertices)
+		_, err = NewChainObject([]vector.Vector{flat[0], flat[0], flat[1]}, false)
+		So(err, ShouldEqual, ErrZeroLengthEdge)
+	})
+
+	Convey("Should collide with convex shapes defined outside the package", t, func() {
+		chain, _ := NewChainObject(flat, false)
+		e := ellipse{vector.NewVector(10, 3), 10, 5}
+		collided, m, err := DetectCollision(&chain, e)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1, 1e-3)
+		So(m.Depth, ShouldAlmostEqual, 2, 1e-3)
+		So(m.SubShapeA, ShouldEqual, 1)
+
+		collided, m, err = DetectCollision(e, &chain)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -1, 1e-3)
+		So(m.SubShapeB, ShouldEqual, 1)
+
+		other, _ := NewChainObject([]vector.Vector{flat[0], flat[1], vector.NewVector(0, 10)}, true)
+		_, _, err = DetectCollision(&chain, &other)
+		So(errors.Is(err, ErrUnknownCollision), ShouldBeTrue)
+	})
+
+	Convey("Should not snag a box sliding across a shared vertex", t, func() {
+		chain, _ := NewChainObject(flat, false)
+		// the box has just crossed onto the second edge, which on its own would push it back sideways
+		box := NewRectangleObject(10, 10, 1, vector.NewVector(-9.995, -0.01))
+		_, m, _ := DetectCollision(segmentShape{flat[1], flat[2]}, &box)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 0)
+
+		collided, m, err := DetectCollision(&chain, &box)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 0)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.01)
+		So(len(m.Points), ShouldEqual, 2)
+		left := math.Min(m.Points[0].GetVals()[0], m.Points[1].GetVals()[0])
+		right := math.Max(m.Points[0].GetVals()[0], m.Points[1].GetVals()[0])
+		So(left, ShouldAlmostEqual, -9.995)
+		So(right, ShouldAlmostEqual, 0, 0.01)
+	})
+
+	Convey("Should keep contacts with a corner the chain turns away at", t, func() {
+		chain, _ := NewChainObject([]vector.Vector{
+			vector.NewVector(-50, 0),
+			vector.NewVector(0, 0),
+			vector.NewVector(50, -50),
+		}, false)
+		ball := NewCircleObject(1, 1, vector.NewVector(0.5, 0.5))
+		collided, m, _ := DetectCollision(&ball, &chain)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, -math.Sqrt2/2)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -math.Sqrt2/2)
+	})
+
+	Convey("Should collide with the inside of a closed chain", t, func() {
+		chain, _ := NewChainObject([]vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(100, 0),
+			vector.NewVector(100, 100),
+			vector.NewVector(0, 100),
+		}, true)
+		ball := NewCircleObject(5, 1, vector.NewVector(50, 98))
+		collided, m, _ := DetectCollision(&chain, &ball)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -1)
+		So(m.Depth, ShouldAlmostEqual, 3)
+
+		ball = NewCircleObject(5, 1, vector.NewVector(50, 50))
+		collided, _, _ = DetectCollision(&chain, &ball)
+		So(collided, ShouldBeFalse)
+	})
+}
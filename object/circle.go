@@ -6,10 +6,12 @@ import (
 
 // NewCircleObject creates a new circle
 func NewCircleObject(r float64, mass float64, position vector.Vector) Circle {
-	return Circle{
+	c := Circle{
 		r,
-		NewGenericObject(mass, position, collisionCircle),
+		NewGenericObject(mass, position, CollisionCircle),
 	}
+	c.SetInertia(mass * r * r / 2)
+	return c
 }
 
 // Circle is an object with physical implementation for a 2D circle
@@ -18,7 +20,12 @@ type Circle struct {
 	GenericObject
 }
 
-// GetRadius returns circle radius. Implements collision.circleCollider
+// GetRadius returns circle radius. Implements collision.CircleCollider
 func (c Circle) GetRadius() float64 {
 	return c.Radius
 }
+
+// Support returns the point on the circle furthest in the direction. Implements Convex
+func (c Circle) Support(direction vector.Vector) vector.Vector {
+	return c.position.Add(direction.Normalize().Scale(c.Radius))
+}
@@ -0,0 +1,206 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"ganymede/vector"
+	"sync"
+)
+
+// CollisionType identifies the shape of a collider, which decides how it is tested against other shapes
+type CollisionType int
+
+// The collision types of the shapes in this package
+const (
+	CollisionCircle CollisionType = iota
+	CollisionBoundingBox
+	CollisionPolygon
+	CollisionCapsule
+	CollisionSegment
+	CollisionChain
+	CollisionCompound
+
+	firstCustomCollisionType
+)
+
+// Collider is implemented by every shape that can collide
+type Collider interface {
+	GetCollisionType() CollisionType
+	GetPosition() vector.Vector
+}
+
+// BoundingBoxCollider is a rectangle, positioned by its corner with the smallest coordinates
+// before it is rotated about its centre
+type BoundingBoxCollider interface {
+	GetDimensions() vector.Vector
+	GetAngle() float64
+	Collider
+}
+
+// CircleCollider is a circle, positioned by its centre
+type CircleCollider interface {
+	GetRadius() float64
+	Collider
+}
+
+// PolygonCollider is a convex polygon with vertices wound anti-clockwise in world space
+type PolygonCollider interface {
+	GetVertices() []vector.Vector
+	Collider
+}
+
+// SegmentCollider is a line segment between two points
+type SegmentCollider interface {
+	GetEndpoints() (vector.Vector, vector.Vector)
+	Collider
+}
+
+// CapsuleCollider is a segment rounded by a radius
+type CapsuleCollider interface {
+	GetRadius() float64
+	SegmentCollider
+}
+
+// ChainCollider is a polyline. When it is closed its last vertex joins its first.
+type ChainCollider interface {
+	GetVertices() []vector.Vector
+	IsClosed() bool
+	Collider
+}
+
+// CompoundCollider is made of several shapes, each tested for collision on its own
+type CompoundCollider interface {
+	GetParts() []Part
+	Collider
+}
+
+// CollisionFunc tests two colliders of the types it was registered for.
+// It returns true if they have collided, and a manifold describing the contact from o1 towards o2.
+type CollisionFunc func(o1, o2 Collider) (bool, Manifold)
+
+// ErrUnknownCollision is returned when there is no way to test a pair of colliders
+var ErrUnknownCollision = errors.New("no collision test registered")
+
+type collisionPair struct {
+	first, second CollisionType
+}
+
+var (
+	registryMutex     sync.RWMutex
+	collisionFuncs    = map[collisionPair]CollisionFunc{}
+	nextCollisionType = firstCustomCollisionType
+)
+
+// NewCollisionType returns a collision type no other shape uses, for shapes defined outside this package
+func NewCollisionType() CollisionType {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	t := nextCollisionType
+	nextCollisionType++
+	return t
+}
+
+// RegisterCollision sets the test for colliders of the first type against colliders of the second.
+// The test is used for the pair in either order, with the manifold flipped when the order is reversed.
+// Registering a pair again replaces its test.
+func RegisterCollision(first, second CollisionType, collide CollisionFunc) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	collisionFuncs[collisionPair{first, second}] = collide
+	if first != second {
+		collisionFuncs[collisionPair{second, first}] = func(o1, o2 Collider) (bool, Manifold) {
+			collided, m := collide(o2, o1)
+			return collided, m.Flip()
+		}
+	}
+}
+
+// DetectCollision returns true if the two colliders have collided,
+// and a manifold describing the contact from o1 towards o2.
+// Pairs without a registered test are tested with GJK if both are Convex,
+// chains are tested edge by edge against any Convex shape,
+// and other pairs return ErrUnknownCollision.
+// The parts of compounds are tested separately, and the deepest contact between them is returned.
+func DetectCollision(o1 Collider, o2 Collider) (bool, Manifold, error) {
+	if !isCompound(o1) && !isCompound(o2) {
+		return detectPair(o1, o2)
+	}
+
+	manifolds, err := DetectCollisions(o1, o2)
+	if err != nil || len(manifolds) == 0 {
+		return false, Manifold{}, err
+	}
+	deepest := manifolds[0]
+	for _, m := range manifolds[1:] {
+		if m.Depth > deepest.Depth {
+			deepest = m
+		}
+	}
+	return true, deepest, nil
+}
+
+// DetectCollisions returns a manifold for each pair of parts of the two colliders that have collided.
+// Each manifold records which parts of compounds it is between.
+// Other colliders are a single part, so two of them have at most one manifold.
+func DetectCollisions(o1 Collider, o2 Collider) ([]Manifold, error) {
+	if c1, ok := o1.(CompoundCollider); ok {
+		manifolds := []Manifold{}
+		for i, part := range c1.GetParts() {
+			partManifolds, err := DetectCollisions(part, o2)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range partManifolds {
+				m.SubShapeA = i
+				manifolds = append(manifolds, m)
+			}
+		}
+		return manifolds, nil
+	}
+
+	if isCompound(o2) {
+		manifolds, err := DetectCollisions(o2, o1)
+		for i, m := range manifolds {
+			manifolds[i] = m.Flip()
+		}
+		return manifolds, err
+	}
+
+	collided, m, err := detectPair(o1, o2)
+	if err != nil || !collided {
+		return nil, err
+	}
+	return []Manifold{m}, nil
+}
+
+func isCompound(o Collider) bool {
+	_, ok := o.(CompoundCollider)
+	return ok
+}
+
+// detectPair tests two colliders that aren't compounds
+func detectPair(o1 Collider, o2 Collider) (bool, Manifold, error) {
+	registryMutex.RLock()
+	collide, ok := collisionFuncs[collisionPair{o1.GetCollisionType(), o2.GetCollisionType()}]
+	registryMutex.RUnlock()
+	if ok {
+		collided, m := collide(o1, o2)
+		return collided, m, nil
+	}
+
+	c1, ok1 := o1.(Convex)
+	c2, ok2 := o2.(Convex)
+	if chain, ok := o1.(ChainCollider); ok && ok2 {
+		collided, m := chainAnd(chain, o2)
+		return collided, m, nil
+	}
+	if chain, ok := o2.(ChainCollider); ok && ok1 {
+		collided, m := chainAnd(chain, o1)
+		return collided, m.Flip(), nil
+	}
+	if !ok1 || !ok2 {
+		return false, Manifold{}, fmt.Errorf("%w between collision types %d and %d", ErrUnknownCollision, o1.GetCollisionType(), o2.GetCollisionType())
+	}
+	collided, m := convexAndConvex(c1, c2)
+	return collided, m, nil
+}
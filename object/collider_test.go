@@ -0,0 +1,67 @@
+package object
+
+import (
+	"errors"
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// point is a shape with no support function, so it can only collide through registered tests
+type point struct {
+	collisionType CollisionType
+	position      vector.Vector
+}
+
+func (p point) GetCollisionType() CollisionType {
+	return p.collisionType
+}
+
+func (p point) GetPosition() vector.Vector {
+	return p.position
+}
+
+func TestCollisionRegistry(t *testing.T) {
+	Convey("Should return new collision types", t, func() {
+		t1 := NewCollisionType()
+		t2 := NewCollisionType()
+		So(t1, ShouldNotEqual, t2)
+		So(t1, ShouldBeGreaterThan, CollisionPolygon)
+	})
+
+	Convey("Should return an error for pairs it can't test", t, func() {
+		p := point{NewCollisionType(), vector.NewVector(0, 0)}
+		c := NewCircleObject(1, 1, vector.NewVector(0, 0))
+		So(func() { DetectCollision(p, &c) }, ShouldNotPanic)
+		collided, _, err := DetectCollision(p, &c)
+		So(collided, ShouldBeFalse)
+		So(errors.Is(err, ErrUnknownCollision), ShouldBeTrue)
+	})
+
+	Convey("Should use registered tests in either order", t, func() {
+		p := point{NewCollisionType(), vector.NewVector(0, 0)}
+		RegisterCollision(p.GetCollisionType(), CollisionCircle, func(o1, o2 Collider) (bool, Manifold) {
+			c := o2.(CircleCollider)
+			toCircle := c.GetPosition().Subtract(o1.GetPosition())
+			depth := c.GetRadius() - toCircle.Magnitude()
+			return depth > 0, Manifold{Normal: toCircle.Normalize(), Depth: depth, Points: []vector.Vector{o1.GetPosition()}}
+		})
+
+		c := NewCircleObject(2, 1, vector.NewVector(1, 0))
+		collided, m, err := DetectCollision(p, &c)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldEqual, 1)
+		So(m.Depth, ShouldEqual, 1)
+
+		collided, m, err = DetectCollision(&c, p)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldEqual, -1)
+
+		other := point{NewCollisionType(), vector.NewVector(0, 0)}
+		_, _, err = DetectCollision(p, other)
+		So(err, ShouldNotBeNil)
+	})
+}
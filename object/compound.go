@@ -0,0 +1,132 @@
+package object
+
+import (
+	"errors"
+	"ganymede/vector"
+)
+
+// ErrNoParts is returned when a compound is created without any parts
+var ErrNoParts = errors.New("compound needs at least one part")
+
+// Part is a shape that can be one of the parts of a compound
+type Part interface {
+	Collider
+	Bounded
+	Raycaster
+	GetMass() float64
+	GetInertia() float64
+	GetCentre() vector.Vector
+	GetAngle() float64
+	// WithPose returns a copy of the part with its centre at the point, turned to the angle,
+	// leaving the part itself where it is
+	WithPose(centre vector.Vector, angle float64) Part
+}
+
+// NewCompoundObject creates a rigid body out of several shapes, such as an L from two rectangles.
+// The parts are placed relative to the position, and keep their angles as they are turned with the body.
+// The compound keeps copies of the parts, so changing or sharing the originals afterwards doesn't affect it.
+// The body's mass, centre of mass and inertia are summed from the masses of the parts.
+func NewCompoundObject(parts []Part, position vector.Vector) (Compound, error) {
+	if len(parts) == 0 {
+		return Compound{}, ErrNoParts
+	}
+
+	mass := 0.0
+	centre := zeroVector(position)
+	for _, p := range parts {
+		mass += p.GetMass()
+		centre = centre.Add(p.GetCentre().Scale(p.GetMass()))
+	}
+	if mass > 0 {
+		centre = centre.Scale(1 / mass)
+	} else {
+		// a compound of static parts turns about the middle of them
+		for _, p := range parts {
+			centre = centre.Add(p.GetCentre().Scale(1 / float64(len(parts))))
+		}
+	}
+
+	c := Compound{
+		GenericObject: NewGenericObject(mass, position, CollisionCompound),
+	}
+	c.centreOffset = centre
+	inertia := 0.0
+	for _, p := range parts {
+		offset := p.GetCentre().Subtract(centre)
+		inertia += p.GetInertia() + p.GetMass()*offset.DotProduct(offset)
+		c.parts = append(c.parts, compoundPart{p.WithPose(p.GetCentre(), p.GetAngle()), offset, p.GetAngle()})
+	}
+	c.SetInertia(inertia)
+	return c, nil
+}
+
+// Compound is an object with physical implementation for a rigid body made of several shapes
+type Compound struct {
+	parts []compoundPart
+	GenericObject
+}
+
+type compoundPart struct {
+	shape  Part
+	offset vector.Vector // from the compound's centre of mass to the part's centre, before rotation
+	angle  float64
+}
+
+// GetParts returns copies of the parts of the compound, placed where the compound is in world space.
+// Implements CompoundCollider
+func (c Compound) GetParts() []Part {
+	centre := c.GetCentre()
+	parts := make([]Part, len(c.parts))
+	for i, p := range c.parts {
+		parts[i] = p.shape.WithPose(centre.Add(rotate(p.offset, c.angle)), c.angle+p.angle)
+	}
+	return parts
+}
+
+// setPose places the object with its centre at the point, turned to the angle
+func (o *GenericObject) setPose(centre vector.Vector, angle float64) {
+	o.position = centre.Subtract(o.centreOffset)
+	o.angle = angle
+}
+
+// WithPose returns a copy of the circle placed at the pose. Implements Part
+func (c Circle) WithPose(centre vector.Vector, angle float64) Part {
+	c.setPose(centre, angle)
+	return &c
+}
+
+// WithPose returns a copy of the rectangle placed at the pose. Implements Part
+func (r Rectangle) WithPose(centre vector.Vector, angle float64) Part {
+	r.setPose(centre, angle)
+	return &r
+}
+
+// WithPose returns a copy of the polygon placed at the pose. Implements Part
+func (p Polygon) WithPose(centre vector.Vector, angle float64) Part {
+	p.setPose(centre, angle)
+	return &p
+}
+
+// WithPose returns a copy of the capsule placed at the pose. Implements Part
+func (c Capsule) WithPose(centre vector.Vector, angle float64) Part {
+	c.setPose(centre, angle)
+	return &c
+}
+
+// WithPose returns a copy of the segment placed at the pose. Implements Part
+func (s Segment) WithPose(centre vector.Vector, angle float64) Part {
+	s.setPose(centre, angle)
+	return &s
+}
+
+// WithPose returns a copy of the chain placed at the pose. Implements Part
+func (c Chain) WithPose(centre vector.Vector, angle float64) Part {
+	c.setPose(centre, angle)
+	return &c
+}
+
+// WithPose returns a copy of the compound placed at the pose. Implements Part
+func (c Compound) WithPose(centre vector.Vector, angle float64) Part {
+	c.setPose(centre, angle)
+	return &c
+}
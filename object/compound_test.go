@@ -0,0 +1,105 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCompound(t *testing.T) {
+	dumbbell := func() Compound {
+		left := NewCircleObject(1, 1, vector.NewVector(-5, 0))
+		right := NewCircleObject(1, 1, vector.NewVector(5, 0))
+		c, _ := NewCompoundObject([]Part{&left, &right}, vector.NewVector(100, 10))
+		return c
+	}
+
+	Convey("Should need at least one part", t, func() {
+		_, err := NewCompoundObject(nil, vector.NewVector(0, 0))
+		So(err, ShouldEqual, ErrNoParts)
+	})
+
+	Convey("Should sum the mass and inertia of its parts", t, func() {
+		c := dumbbell()
+		So(c.GetMass(), ShouldEqual, 2)
+		So(c.GetCentre().GetVals()[0], ShouldAlmostEqual, 100)
+		So(c.GetCentre().GetVals()[1], ShouldAlmostEqual, 10)
+		So(c.GetInertia(), ShouldAlmostEqual, 2*(0.5+25))
+	})
+
+	Convey("Should find the centre of mass of an L", t, func() {
+		base := NewRectangleObject(30, 10, 3, vector.NewVector(0, 0))
+		upright := NewRectangleObject(10, 20, 2, vector.NewVector(0, 10))
+		c, err := NewCompoundObject([]Part{&base, &upright}, vector.NewVector(0, 0))
+		So(err, ShouldBeNil)
+		So(c.GetMass(), ShouldEqual, 5)
+		So(c.GetCentre().GetVals()[0], ShouldAlmostEqual, (3*15+2*5)/5.0)
+		So(c.GetCentre().GetVals()[1], ShouldAlmostEqual, (3*5+2*20)/5.0)
+	})
+
+	Convey("Should carry its parts as it moves and turns", t, func() {
+		c := dumbbell()
+		c.AdjustPosition(vector.NewVector(0, 5))
+		c.SetAngle(math.Pi / 2)
+		parts := c.GetParts()
+		So(parts[1].GetCentre().GetVals()[0], ShouldAlmostEqual, 100)
+		So(parts[1].GetCentre().GetVals()[1], ShouldAlmostEqual, 20)
+		So(parts[0].GetCentre().GetVals()[1], ShouldAlmostEqual, 10)
+		So(parts[0].GetAngle(), ShouldAlmostEqual, math.Pi/2)
+	})
+
+	Convey("Should leave the shapes it was made from where they are", t, func() {
+		left := NewCircleObject(1, 1, vector.NewVector(-5, 0))
+		right := NewCircleObject(1, 1, vector.NewVector(5, 0))
+		first, _ := NewCompoundObject([]Part{&left, &right}, vector.NewVector(100, 10))
+		second, _ := NewCompoundObject([]Part{&left, &right}, vector.NewVector(-100, 10))
+		second.SetAngle(math.Pi)
+
+		firstParts := first.GetParts()
+		secondParts := second.GetParts()
+		So(left.GetPosition().GetVals()[0], ShouldEqual, -5)
+		So(right.GetPosition().GetVals()[0], ShouldEqual, 5)
+		So(firstParts[0].GetCentre().GetVals()[0], ShouldAlmostEqual, 95)
+		So(secondParts[0].GetCentre().GetVals()[0], ShouldAlmostEqual, -95)
+		So(firstParts[0].GetAngle(), ShouldEqual, 0)
+
+		right.AdjustPosition(vector.NewVector(50, 0))
+		So(first.GetParts()[1].GetCentre().GetVals()[0], ShouldAlmostEqual, 105)
+	})
+
+	Convey("Should report which part was hit", t, func() {
+		c := dumbbell()
+		ball := NewCircleObject(1, 1, vector.NewVector(106.5, 10))
+
+		collided, m, err := DetectCollision(&c, &ball)
+		So(err, ShouldBeNil)
+		So(collided, ShouldBeTrue)
+		So(m.SubShapeA, ShouldEqual, 1)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.5)
+
+		collided, m, _ = DetectCollision(&ball, &c)
+		So(collided, ShouldBeTrue)
+		So(m.SubShapeA, ShouldEqual, 0)
+		So(m.SubShapeB, ShouldEqual, 1)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+	})
+
+	Convey("Should return a manifold for each part that touches", t, func() {
+		c := dumbbell()
+		floor := NewRectangleObject(1000, 10, 0, vector.NewVector(0, 0))
+		c.AdjustPosition(vector.NewVector(0, 0.9))
+
+		manifolds, err := DetectCollisions(&floor, &c)
+		So(err, ShouldBeNil)
+		So(len(manifolds), ShouldEqual, 2)
+		So(manifolds[0].SubShapeB, ShouldEqual, 0)
+		So(manifolds[1].SubShapeB, ShouldEqual, 1)
+		for _, m := range manifolds {
+			So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+			So(m.Depth, ShouldAlmostEqual, 0.1)
+		}
+	})
+}
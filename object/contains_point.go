@@ -0,0 +1,54 @@
+package object
+
+import (
+	"ganymede/vector"
+)
+
+// PointContainer is implemented by shapes with an inside, which can say whether points are in them.
+// Segments and chains have no inside, so they never contain points.
+type PointContainer interface {
+	// ContainsPoint returns true if the point, in world space, is inside the shape or on its edge
+	ContainsPoint(point vector.Vector) bool
+}
+
+// isPointInsidePolygon reports whether the point is inside or on the edge of a convex polygon wound anti-clockwise
+func isPointInsidePolygon(point vector.Vector, vertices []vector.Vector) bool {
+	for i, v := range vertices {
+		next := vertices[(i+1)%len(vertices)]
+		if next.Subtract(v).PerpDotProduct(point.Subtract(v)) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsPoint returns true if the point is inside the circle. Implements PointContainer
+func (c Circle) ContainsPoint(point vector.Vector) bool {
+	return point.Subtract(c.position).Magnitude() <= c.Radius
+}
+
+// ContainsPoint returns true if the point is inside the rectangle after rotation. Implements PointContainer
+func (r Rectangle) ContainsPoint(point vector.Vector) bool {
+	return isPointInsidePolygon(point, r.GetCorners())
+}
+
+// ContainsPoint returns true if the point is inside the polygon. Implements PointContainer
+func (p Polygon) ContainsPoint(point vector.Vector) bool {
+	return isPointInsidePolygon(point, p.GetVertices())
+}
+
+// ContainsPoint returns true if the point is inside the capsule. Implements PointContainer
+func (c Capsule) ContainsPoint(point vector.Vector) bool {
+	start, end := c.GetEndpoints()
+	return point.Subtract(nearestPointOnSegment(point, start, end)).Magnitude() <= c.Radius
+}
+
+// ContainsPoint returns true if the point is inside any of the parts of the compound. Implements PointContainer
+func (c Compound) ContainsPoint(point vector.Vector) bool {
+	for _, p := range c.GetParts() {
+		if container, ok := p.(PointContainer); ok && container.ContainsPoint(point) {
+			return true
+		}
+	}
+	return false
+}
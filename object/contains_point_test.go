@@ -0,0 +1,49 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestContainsPoint(t *testing.T) {
+	Convey("Should contain points inside a circle and on its edge", t, func() {
+		c := NewCircleObject(10, 1, vector.NewVector(0, 0))
+		So(c.ContainsPoint(vector.NewVector(3, 4)), ShouldBeTrue)
+		So(c.ContainsPoint(vector.NewVector(10, 0)), ShouldBeTrue)
+		So(c.ContainsPoint(vector.NewVector(8, 8)), ShouldBeFalse)
+	})
+
+	Convey("Should contain points inside a rectangle after it turns", t, func() {
+		r := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		So(r.ContainsPoint(vector.NewVector(9, 9)), ShouldBeTrue)
+
+		r.SetAngle(math.Pi / 4)
+		So(r.ContainsPoint(vector.NewVector(9, 9)), ShouldBeFalse)
+		So(r.ContainsPoint(vector.NewVector(5, 11)), ShouldBeTrue)
+	})
+
+	Convey("Should contain points inside a polygon", t, func() {
+		p, _ := NewPolygonObject([]vector.Vector{
+			vector.NewVector(0, 0), vector.NewVector(10, 0), vector.NewVector(0, 10),
+		}, 1, vector.NewVector(0, 0))
+		So(p.ContainsPoint(vector.NewVector(2, 2)), ShouldBeTrue)
+		So(p.ContainsPoint(vector.NewVector(6, 6)), ShouldBeFalse)
+	})
+
+	Convey("Should contain points inside a capsule's rounded ends", t, func() {
+		c := NewCapsuleObject(10, 2, 1, vector.NewVector(0, 0))
+		So(c.ContainsPoint(vector.NewVector(0, 6.5)), ShouldBeTrue)
+		So(c.ContainsPoint(vector.NewVector(1.9, 5.9)), ShouldBeFalse)
+	})
+
+	Convey("Should contain points inside any part of a compound", t, func() {
+		left := NewCircleObject(5, 1, vector.NewVector(-10, 0))
+		right := NewCircleObject(5, 1, vector.NewVector(10, 0))
+		c, _ := NewCompoundObject([]Part{&left, &right}, vector.NewVector(0, 0))
+		So(c.ContainsPoint(vector.NewVector(12, 0)), ShouldBeTrue)
+		So(c.ContainsPoint(vector.NewVector(0, 0)), ShouldBeFalse)
+	})
+}
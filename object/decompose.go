@@ -0,0 +1,227 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"ganymede/vector"
+)
+
+// ErrSelfIntersecting is returned when a polygon's edges cross each other
+var ErrSelfIntersecting = errors.New("polygon is self-intersecting")
+
+// NewConcavePolygonObject creates a compound body from an outline that may be concave.
+// The outline is split into convex polygons by Decompose, and the mass is shared between them by area.
+// Like NewPolygonObject, the vertices are relative to the position and may be wound either way.
+func NewConcavePolygonObject(vertices []vector.Vector, mass float64, position vector.Vector) (Compound, error) {
+	pieces, err := Decompose(vertices)
+	if err != nil {
+		return Compound{}, err
+	}
+
+	area := signedArea(vertices)
+	if area < 0 {
+		area = -area
+	}
+	parts := []Part{}
+	for _, piece := range pieces {
+		part, err := NewPolygonObject(piece, mass*signedArea(piece)/area, zeroVector(position))
+		if err != nil {
+			return Compound{}, err
+		}
+		parts = append(parts, &part)
+	}
+	return NewCompoundObject(parts, position)
+}
+
+// Decompose splits a simple polygon, which may be concave, into convex polygons wound anti-clockwise.
+// The polygon is cut into triangles by ear clipping, then neighbouring pieces are merged back together
+// wherever the result is still convex (Hertel-Mehlhorn), which gives at most four times the fewest possible pieces.
+// Repeated and collinear vertices are ignored, and polygons whose edges cross return ErrSelfIntersecting.
+func Decompose(vertices []vector.Vector) ([][]vector.Vector, error) {
+	outline := withoutRedundantVertices(vertices)
+	if len(outline) < 3 {
+		return nil, ErrTooFewVertices
+	}
+	if signedArea(outline) < 0 {
+		for i, j := 0, len(outline)-1; i < j; i, j = i+1, j-1 {
+			outline[i], outline[j] = outline[j], outline[i]
+		}
+	}
+	if err := checkSimple(outline); err != nil {
+		return nil, err
+	}
+
+	pieces := mergeConvexPieces(outline, triangulate(outline))
+	polygons := make([][]vector.Vector, len(pieces))
+	for i, piece := range pieces {
+		polygons[i] = verticesOf(outline, piece)
+	}
+	return polygons, nil
+}
+
+// withoutRedundantVertices removes vertices that repeat the one before them or lie on a line between their neighbours
+func withoutRedundantVertices(vertices []vector.Vector) []vector.Vector {
+	outline := append([]vector.Vector{}, vertices...)
+	for removed := true; removed && len(outline) >= 3; {
+		removed = false
+		for i := range outline {
+			previous := outline[(i-1+len(outline))%len(outline)]
+			next := outline[(i+1)%len(outline)]
+			if outline[i].Subtract(previous).PerpDotProduct(next.Subtract(outline[i])) == 0 {
+				outline = append(outline[:i], outline[i+1:]...)
+				removed = true
+				break
+			}
+		}
+	}
+	return outline
+}
+
+// checkSimple returns an error describing the first pair of edges found to cross
+func checkSimple(outline []vector.Vector) error {
+	n := len(outline)
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				// the first and last edges share a vertex
+				continue
+			}
+			if segmentsIntersect(outline[i], outline[(i+1)%n], outline[j], outline[(j+1)%n]) {
+				return fmt.Errorf("%w: edge %d from %v to %v crosses edge %d from %v to %v", ErrSelfIntersecting,
+					i, outline[i].GetVals(), outline[(i+1)%n].GetVals(), j, outline[j].GetVals(), outline[(j+1)%n].GetVals())
+			}
+		}
+	}
+	return nil
+}
+
+// segmentsIntersect reports whether the segment from a1 to b1 touches the segment from a2 to b2
+func segmentsIntersect(a1, b1, a2, b2 vector.Vector) bool {
+	side1 := orientation(a2, b2, a1)
+	side2 := orientation(a2, b2, b1)
+	side3 := orientation(a1, b1, a2)
+	side4 := orientation(a1, b1, b2)
+	if side1*side2 < 0 && side3*side4 < 0 {
+		return true
+	}
+
+	// an end of one segment may lie on the other
+	return side1 == 0 && isBetween(a1, a2, b2) ||
+		side2 == 0 && isBetween(b1, a2, b2) ||
+		side3 == 0 && isBetween(a2, a1, b1) ||
+		side4 == 0 && isBetween(b2, a1, b1)
+}
+
+// orientation is positive if c is left of the line from a to b, negative if it is right and 0 if it is on it
+func orientation(a, b, c vector.Vector) float64 {
+	return b.Subtract(a).PerpDotProduct(c.Subtract(a))
+}
+
+// isBetween reports whether p, which is on the line through a and b, is between them
+func isBetween(p, a, b vector.Vector) bool {
+	return p.Subtract(a).DotProduct(p.Subtract(b)) <= 0
+}
+
+// triangulate cuts the polygon, wound anti-clockwise, into triangles by repeatedly clipping off ears:
+// corners that turn left with no other vertex inside them.
+// The triangles are returned as indices into the polygon's vertices.
+func triangulate(outline []vector.Vector) [][]int {
+	remaining := make([]int, len(outline))
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	triangles := [][]int{}
+	for len(remaining) > 3 {
+		clipped := false
+		for i := range remaining {
+			n := len(remaining)
+			previous, corner, next := remaining[(i-1+n)%n], remaining[i], remaining[(i+1)%n]
+			if !isEar(outline, remaining, previous, corner, next) {
+				continue
+			}
+			triangles = append(triangles, []int{previous, corner, next})
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			clipped = true
+			break
+		}
+		if !clipped {
+			// only possible through rounding, as every simple polygon has an ear
+			break
+		}
+	}
+	return append(triangles, remaining)
+}
+
+func isEar(outline []vector.Vector, remaining []int, previous, corner, next int) bool {
+	a, b, c := outline[previous], outline[corner], outline[next]
+	if b.Subtract(a).PerpDotProduct(c.Subtract(b)) <= 0 {
+		return false
+	}
+	for _, v := range remaining {
+		if v == previous || v == corner || v == next {
+			continue
+		}
+		if isPointInTriangle(outline[v], a, b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPointInTriangle reports whether the point is inside or on the edge of the triangle, wound anti-clockwise
+func isPointInTriangle(p, a, b, c vector.Vector) bool {
+	return b.Subtract(a).PerpDotProduct(p.Subtract(a)) >= 0 &&
+		c.Subtract(b).PerpDotProduct(p.Subtract(b)) >= 0 &&
+		a.Subtract(c).PerpDotProduct(p.Subtract(c)) >= 0
+}
+
+// mergeConvexPieces removes the diagonals between pieces wherever the piece they would merge into is convex
+func mergeConvexPieces(outline []vector.Vector, pieces [][]int) [][]int {
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(pieces) && !merged; i++ {
+			for j := i + 1; j < len(pieces) && !merged; j++ {
+				piece, ok := mergePieces(pieces[i], pieces[j])
+				if !ok || !isConvex(verticesOf(outline, piece)) {
+					continue
+				}
+				pieces[i] = piece
+				pieces = append(pieces[:j], pieces[j+1:]...)
+				merged = true
+			}
+		}
+	}
+	return pieces
+}
+
+// mergePieces joins two pieces along an edge they share, if they share one
+func mergePieces(p, q []int) ([]int, bool) {
+	for i := range p {
+		a, b := p[i], p[(i+1)%len(p)]
+		for j := range q {
+			if q[j] != b || q[(j+1)%len(q)] != a {
+				continue
+			}
+
+			// walk p from b round to a, then q from a round to b, without repeating the shared ends
+			merged := []int{}
+			for k := 1; k <= len(p); k++ {
+				merged = append(merged, p[(i+k)%len(p)])
+			}
+			for k := 2; k < len(q); k++ {
+				merged = append(merged, q[(j+k)%len(q)])
+			}
+			return merged, true
+		}
+	}
+	return nil, false
+}
+
+func verticesOf(outline []vector.Vector, piece []int) []vector.Vector {
+	vertices := make([]vector.Vector, len(piece))
+	for i, v := range piece {
+		vertices[i] = outline[v]
+	}
+	return vertices
+}
@@ -0,0 +1,123 @@
+package object
+
+import (
+	"errors"
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecompose(t *testing.T) {
+	l := []vector.Vector{
+		vector.NewVector(0, 0),
+		vector.NewVector(30, 0),
+		vector.NewVector(30, 10),
+		vector.NewVector(10, 10),
+		vector.NewVector(10, 30),
+		vector.NewVector(0, 30),
+	}
+
+	totalArea := func(pieces [][]vector.Vector) float64 {
+		area := 0.0
+		for _, piece := range pieces {
+			area += signedArea(piece)
+		}
+		return area
+	}
+
+	Convey("Should leave a convex polygon whole", t, func() {
+		pieces, err := Decompose([]vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(10, 0),
+			vector.NewVector(10, 10),
+			vector.NewVector(0, 10),
+		})
+		So(err, ShouldBeNil)
+		So(len(pieces), ShouldEqual, 1)
+		So(len(pieces[0]), ShouldEqual, 4)
+	})
+
+	Convey("Should split an L into two convex pieces", t, func() {
+		pieces, err := Decompose(l)
+		So(err, ShouldBeNil)
+		So(len(pieces), ShouldEqual, 2)
+		So(totalArea(pieces), ShouldAlmostEqual, 500)
+		for _, piece := range pieces {
+			So(isConvex(piece), ShouldBeTrue)
+		}
+	})
+
+	Convey("Should accept either winding", t, func() {
+		clockwise := []vector.Vector{}
+		for i := len(l) - 1; i >= 0; i-- {
+			clockwise = append(clockwise, l[i])
+		}
+		pieces, err := Decompose(clockwise)
+		So(err, ShouldBeNil)
+		So(len(pieces), ShouldEqual, 2)
+		So(totalArea(pieces), ShouldAlmostEqual, 500)
+	})
+
+	Convey("Should split a comb into convex pieces", t, func() {
+		comb := []vector.Vector{vector.NewVector(0, 0), vector.NewVector(50, 0)}
+		for x := 50.0; x > 0; x -= 10 {
+			comb = append(comb,
+				vector.NewVector(x, 20),
+				vector.NewVector(x-5, 20),
+				vector.NewVector(x-5, 10),
+				vector.NewVector(x-10, 10),
+			)
+		}
+		pieces, err := Decompose(comb)
+		So(err, ShouldBeNil)
+		So(len(pieces), ShouldBeLessThanOrEqualTo, 4*6)
+		So(totalArea(pieces), ShouldAlmostEqual, 50*10+5*5*10)
+		for _, piece := range pieces {
+			So(isConvex(piece), ShouldBeTrue)
+		}
+	})
+
+	Convey("Should ignore repeated and collinear vertices", t, func() {
+		pieces, err := Decompose([]vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(5, 0),
+			vector.NewVector(10, 0),
+			vector.NewVector(10, 0),
+			vector.NewVector(10, 10),
+			vector.NewVector(0, 10),
+		})
+		So(err, ShouldBeNil)
+		So(len(pieces), ShouldEqual, 1)
+		So(len(pieces[0]), ShouldEqual, 4)
+	})
+
+	Convey("Should reject self-intersecting polygons", t, func() {
+		bowTie := []vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(10, 10),
+			vector.NewVector(10, 0),
+			vector.NewVector(0, 10),
+		}
+		_, err := Decompose(bowTie)
+		So(errors.Is(err, ErrSelfIntersecting), ShouldBeTrue)
+		So(err.Error(), ShouldContainSubstring, "edge 0")
+		So(err.Error(), ShouldContainSubstring, "edge 2")
+
+		_, err = Decompose(l[:2])
+		So(err, ShouldEqual, ErrTooFewVertices)
+	})
+
+	Convey("Should build a compound body from a concave outline", t, func() {
+		c, err := NewConcavePolygonObject(l, 5, vector.NewVector(100, 100))
+		So(err, ShouldBeNil)
+		So(len(c.GetParts()), ShouldEqual, 2)
+		So(c.GetMass(), ShouldAlmostEqual, 5)
+
+		// the L is a 30x10 bar and a 10x20 upright
+		centreX := (300*15 + 200*5) / 500.0
+		centreY := (300*5 + 200*20) / 500.0
+		So(c.GetCentre().GetVals()[0], ShouldAlmostEqual, 100+centreX)
+		So(c.GetCentre().GetVals()[1], ShouldAlmostEqual, 100+centreY)
+	})
+}
@@ -5,103 +5,166 @@ import (
 	"math"
 )
 
-// Type defines an objects collision type
-type collisionType = int
-
-const (
-	collisionCircle = iota
-	collisionBoundingBox
-)
-
-type collider interface {
-	GetCollisionType() collisionType
-	GetPosition() vector.Vector
-}
-
-type boundingBoxCollider interface {
-	GetDimensions() vector.Vector
-	collider
-}
-
-type circleCollider interface {
-	GetRadius() float64
-	collider
-}
-
-// DetectCollision returns true if the two objects have collided
-func DetectCollision(o1 collider, o2 collider) (bool, vector.Vector) {
-	o1Type := o1.GetCollisionType()
-
-	switch o1Type {
-	case collisionCircle:
-		c1 := o1.(circleCollider)
-		return circleAnd(c1, o2)
-	case collisionBoundingBox:
-		b1 := o1.(boundingBoxCollider)
-		return boundingBoxAnd(b1, o2)
-	default:
-		panic("Unknown collision type")
+func init() {
+	RegisterCollision(CollisionCircle, CollisionCircle, func(o1, o2 Collider) (bool, Manifold) {
+		return circleAndCircle(o1.(CircleCollider), o2.(CircleCollider))
+	})
+	RegisterCollision(CollisionCircle, CollisionBoundingBox, func(o1, o2 Collider) (bool, Manifold) {
+		return circleAndBB(o1.(CircleCollider), o2.(BoundingBoxCollider))
+	})
+	RegisterCollision(CollisionCircle, CollisionPolygon, func(o1, o2 Collider) (bool, Manifold) {
+		return circleAndPolygon(o1.(CircleCollider), o2.(PolygonCollider).GetVertices())
+	})
+	RegisterCollision(CollisionBoundingBox, CollisionBoundingBox, func(o1, o2 Collider) (bool, Manifold) {
+		return bBAndOrientedBB(o1.(BoundingBoxCollider), o2.(BoundingBoxCollider))
+	})
+	RegisterCollision(CollisionBoundingBox, CollisionPolygon, func(o1, o2 Collider) (bool, Manifold) {
+		return satPolygons(boundingBoxCorners(o1.(BoundingBoxCollider)), o2.(PolygonCollider).GetVertices())
+	})
+	RegisterCollision(CollisionPolygon, CollisionPolygon, func(o1, o2 Collider) (bool, Manifold) {
+		return satPolygons(o1.(PolygonCollider).GetVertices(), o2.(PolygonCollider).GetVertices())
+	})
+	for _, t := range []CollisionType{CollisionCapsule, CollisionSegment} {
+		RegisterCollision(t, CollisionCircle, func(o1, o2 Collider) (bool, Manifold) {
+			start, end, radius := roundedSegment(o1)
+			return roundedSegmentAndCircle(start, end, radius, o2.(CircleCollider))
+		})
+		RegisterCollision(t, CollisionBoundingBox, func(o1, o2 Collider) (bool, Manifold) {
+			start, end, radius := roundedSegment(o1)
+			return roundedSegmentAndPolygon(start, end, radius, boundingBoxCorners(o2.(BoundingBoxCollider)), 0)
+		})
+		RegisterCollision(t, CollisionPolygon, func(o1, o2 Collider) (bool, Manifold) {
+			start, end, radius := roundedSegment(o1)
+			return roundedSegmentAndPolygon(start, end, radius, o2.(PolygonCollider).GetVertices(), 0)
+		})
+	}
+	for _, pair := range []collisionPair{
+		{CollisionCapsule, CollisionCapsule},
+		{CollisionCapsule, CollisionSegment},
+		{CollisionSegment, CollisionSegment},
+	} {
+		RegisterCollision(pair.first, pair.second, func(o1, o2 Collider) (bool, Manifold) {
+			start, end, radius := roundedSegment(o1)
+			otherStart, otherEnd, otherRadius := roundedSegment(o2)
+			return roundedSegmentAndPolygon(start, end, radius, []vector.Vector{otherStart, otherEnd}, otherRadius)
+		})
 	}
 }
 
-func circleAnd(c1 circleCollider, o2 collider) (bool, vector.Vector) {
-	o2Type := o2.GetCollisionType()
-	switch o2Type {
-	case collisionCircle:
-		c2 := o2.(circleCollider)
-		return circleAndCircle(c1, c2)
-	case collisionBoundingBox:
-		b2 := o2.(boundingBoxCollider)
-		return circleAndBB(c1, b2)
-	default:
-		panic("Unknown collision type")
+// roundedSegment returns the segment of a capsule and its radius, or a bare segment with no radius
+func roundedSegment(o Collider) (vector.Vector, vector.Vector, float64) {
+	radius := 0.0
+	if c, ok := o.(CapsuleCollider); ok {
+		radius = c.GetRadius()
 	}
+	start, end := o.(SegmentCollider).GetEndpoints()
+	return start, end, radius
 }
 
-func boundingBoxAnd(b1 boundingBoxCollider, o2 collider) (bool, vector.Vector) {
-	o2Type := o2.GetCollisionType()
-	switch o2Type {
-	case collisionCircle:
-		c2 := o2.(circleCollider)
-		return circleAndBB(c2, b1)
-	case collisionBoundingBox:
-		b2 := o2.(boundingBoxCollider)
+// bBAndOrientedBB tests two boxes, using the cheaper axis-aligned test when neither is rotated
+func bBAndOrientedBB(b1 BoundingBoxCollider, b2 BoundingBoxCollider) (bool, Manifold) {
+	if b1.GetAngle() == 0 && b2.GetAngle() == 0 {
 		return bBAndBB(b1, b2)
-	default:
-		panic("Unknown collision type")
 	}
+	return satPolygons(boundingBoxCorners(b1), boundingBoxCorners(b2))
 }
 
-func bBAndBB(b1 boundingBoxCollider, b2 boundingBoxCollider) (bool, vector.Vector) {
-	topLeft1 := b1.GetPosition()
-	topLeft2 := b2.GetPosition()
+func bBAndBB(b1 BoundingBoxCollider, b2 BoundingBoxCollider) (bool, Manifold) {
+	topLeft1 := b1.GetPosition().GetVals()
+	topLeft2 := b2.GetPosition().GetVals()
 
-	bottomRight1 := boundingBoxBottomRight(b1)
-	bottomRight2 := boundingBoxBottomRight(b2)
+	bottomRight1 := boundingBoxBottomRight(b1).GetVals()
+	bottomRight2 := boundingBoxBottomRight(b2).GetVals()
 
-	// if top left of one is lower than bottom right of other, or vice versa, no overlap
-	// if top left of one is to right of bottom right of other, or vice versa, no overlap
+	// b2 can be pushed clear of b1 along each axis in either direction.
+	// The minimum translation vector is the shortest of these pushes.
+	axis := -1
+	sign := 0.0
+	depth := math.Inf(1)
+	for i := range topLeft1 {
+		forwards := bottomRight1[i] - topLeft2[i]
+		backwards := bottomRight2[i] - topLeft1[i]
 
-	oneAboveTwo := topLeft1.Subtract(bottomRight2)
-	twoAboveOne := topLeft2.Subtract(bottomRight1)
+		// if top left of one is past bottom right of other, no overlap
+		if forwards < 0 || backwards < 0 {
+			return false, Manifold{}
+		}
+
+		if forwards < depth {
+			axis, sign, depth = i, 1, forwards
+		}
+		if backwards < depth {
+			axis, sign, depth = i, -1, backwards
+		}
+	}
 
-	for _, val := range append(oneAboveTwo.GetVals(), twoAboveOne.GetVals()...) {
-		if val > 0 {
-			return false, vector.Vector{}
+	normalVals := make([]float64, len(topLeft1))
+	normalVals[axis] = sign
+
+	// the points sit midway through the overlap, at the ends of the edge the boxes share
+	var faceCoord float64
+	if sign > 0 {
+		faceCoord = bottomRight1[axis] - depth/2
+	} else {
+		faceCoord = topLeft1[axis] + depth/2
+	}
+	points := []vector.Vector{}
+	for _, corner := range [][]float64{topLeft1, bottomRight1} {
+		pointVals := make([]float64, len(topLeft1))
+		for i := range pointVals {
+			pointVals[i] = math.Min(math.Max(corner[i], topLeft2[i]), bottomRight2[i])
 		}
+		pointVals[axis] = faceCoord
+		points = append(points, vector.NewVector(pointVals...))
+	}
+	if points[0].Subtract(points[1]).Magnitude() == 0 {
+		points = points[:1]
 	}
 
-	return true, vector.Vector{}
+	return true, Manifold{Normal: vector.NewVector(normalVals...), Depth: depth, Points: points}
 }
 
-func boundingBoxBottomRight(b boundingBoxCollider) vector.Vector {
+func boundingBoxBottomRight(b BoundingBoxCollider) vector.Vector {
 	return b.GetPosition().Add(b.GetDimensions())
 }
 
-func circleAndCircle(c1 circleCollider, c2 circleCollider) (bool, vector.Vector) {
+func boundingBoxCentre(b BoundingBoxCollider) vector.Vector {
+	return b.GetPosition().Add(b.GetDimensions().Scale(0.5))
+}
+
+// boundingBoxCorners returns the corners of the box in world space, wound anti-clockwise
+func boundingBoxCorners(b BoundingBoxCollider) []vector.Vector {
+	centre := boundingBoxCentre(b)
+	half := b.GetDimensions().Scale(0.5).GetVals()
+	corners := []vector.Vector{
+		vector.NewVector(-half[0], -half[1]),
+		vector.NewVector(half[0], -half[1]),
+		vector.NewVector(half[0], half[1]),
+		vector.NewVector(-half[0], half[1]),
+	}
+	for i, c := range corners {
+		corners[i] = centre.Add(rotate(c, b.GetAngle()))
+	}
+	return corners
+}
+
+func circleAndCircle(c1 CircleCollider, c2 CircleCollider) (bool, Manifold) {
 	maxDistance := c1.GetRadius() + c2.GetRadius()
-	collided := !distanceBetweenPointsIsGreaterThan(c1.GetPosition(), c2.GetPosition(), maxDistance)
-	return collided, c1.GetPosition().Subtract(c2.GetPosition())
+	if distanceBetweenPointsIsGreaterThan(c1.GetPosition(), c2.GetPosition(), maxDistance) {
+		return false, Manifold{}
+	}
+
+	between := c2.GetPosition().Subtract(c1.GetPosition())
+	distance := between.Magnitude()
+	normal := between.Normalize()
+	if distance == 0 {
+		// concentric circles can be separated in any direction
+		normal = vector.NewVector(0, 1)
+	}
+
+	depth := maxDistance - distance
+	point := c1.GetPosition().Add(normal.Scale(c1.GetRadius() - depth/2))
+	return true, Manifold{Normal: normal, Depth: depth, Points: []vector.Vector{point}}
 }
 
 func distanceBetweenPointsIsGreaterThan(p1, p2 vector.Vector, distance float64) bool {
@@ -111,39 +174,83 @@ func distanceBetweenPointsIsGreaterThan(p1, p2 vector.Vector, distance float64)
 	return distanceSq > dSq
 }
 
-func circleAndBB(c circleCollider, b boundingBoxCollider) (bool, vector.Vector) {
+func circleAndBB(c CircleCollider, b BoundingBoxCollider) (bool, Manifold) {
+	if b.GetAngle() != 0 {
+		return circleAndOrientedBB(c, b)
+	}
+
 	// is circle centre inside box?
+	if isPointInsidePolygon(c.GetPosition(), boundingBoxCorners(b)) {
+		return true, circleCentreInBB(c, b)
+	}
+
 	pointNearestToCentre := nearestBoundingBoxEdge(c.GetPosition(), b)
-	diffVector := c.GetPosition().Subtract(pointNearestToCentre)
-	if isPointInsideBox(c.GetPosition(), b) {
-		return true, diffVector.Scale(-1)
+	if distanceBetweenPointsIsGreaterThan(c.GetPosition(), pointNearestToCentre, c.GetRadius()) {
+		return false, Manifold{}
 	}
 
-	collided := !distanceBetweenPointsIsGreaterThan(c.GetPosition(), pointNearestToCentre, c.GetRadius())
-	return collided, diffVector
+	toBox := pointNearestToCentre.Subtract(c.GetPosition())
+	depth := c.GetRadius() - toBox.Magnitude()
+	return true, Manifold{Normal: toBox.Normalize(), Depth: depth, Points: []vector.Vector{pointNearestToCentre}}
 }
 
-func isPointInsideBox(point vector.Vector, b boundingBoxCollider) bool {
-	bbTopLeft := b.GetPosition()
-	bbBottomRight := boundingBoxBottomRight(b)
+// circleAndOrientedBB tests a circle against a rotated box by turning the circle into the box's frame,
+// where the box is axis-aligned, then turning the manifold back.
+// In the box's frame, the axis of least penetration is always one of its face normals
+// or the direction to its nearest point, so this is equivalent to separating axis tests.
+func circleAndOrientedBB(c CircleCollider, b BoundingBoxCollider) (bool, Manifold) {
+	centre := boundingBoxCentre(b)
+	angle := b.GetAngle()
+
+	localCentre := centre.Add(rotate(c.GetPosition().Subtract(centre), -angle))
+	localCircle := circleShape{localCentre, c.GetRadius()}
+	localBox := boxShape{b.GetPosition(), b.GetDimensions(), 0}
+
+	collided, m := circleAndBB(localCircle, localBox)
+	if !collided {
+		return false, m
+	}
 
-	// is it above or left of top left?
-	// is it below or right of bottom right?
-	// if yes to any, not inside the box
+	m.Normal = rotate(m.Normal, angle)
+	for i, p := range m.Points {
+		m.Points[i] = centre.Add(rotate(p.Subtract(centre), angle))
+	}
+	return true, m
+}
 
-	vToTopLeft := point.Subtract(bbTopLeft)
-	vToBottomRight := bbBottomRight.Subtract(point)
+// circleCentreInBB finds the manifold for a circle whose centre is inside the box.
+// The circle is pushed out through the nearest face.
+func circleCentreInBB(c CircleCollider, b BoundingBoxCollider) Manifold {
+	centre := c.GetPosition().GetVals()
+	topLeft := b.GetPosition().GetVals()
+	bottomRight := boundingBoxBottomRight(b).GetVals()
 
-	for _, val := range append(vToTopLeft.GetVals(), vToBottomRight.GetVals()...) {
-		if val < 0 {
-			return false
+	nearestFace := -1
+	faceDistance := math.Inf(1)
+	faceSign := 0.0
+	for i := range centre {
+		if d := centre[i] - topLeft[i]; d < faceDistance {
+			nearestFace, faceDistance, faceSign = i, d, -1
+		}
+		if d := bottomRight[i] - centre[i]; d < faceDistance {
+			nearestFace, faceDistance, faceSign = i, d, 1
 		}
 	}
 
-	return true
+	// the normal points from the circle into the box, away from the nearest face
+	normalVals := make([]float64, len(centre))
+	normalVals[nearestFace] = -faceSign
+	pointVals := append([]float64{}, centre...)
+	pointVals[nearestFace] += faceSign * faceDistance
+
+	return Manifold{
+		Normal: vector.NewVector(normalVals...),
+		Depth:  c.GetRadius() + faceDistance,
+		Points: []vector.Vector{vector.NewVector(pointVals...)},
+	}
 }
 
-func nearestBoundingBoxEdge(point vector.Vector, b boundingBoxCollider) vector.Vector {
+func nearestBoundingBoxEdge(point vector.Vector, b BoundingBoxCollider) vector.Vector {
 	topLeft := b.GetPosition()
 	bottomRight := boundingBoxBottomRight(b)
 
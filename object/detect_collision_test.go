@@ -2,6 +2,7 @@ package object
 
 import (
 	"ganymede/vector"
+	"math"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -11,14 +12,14 @@ func TestCircleCollisions(t *testing.T) {
 	Convey("Should detect circle collision", t, func() {
 		c1 := NewCircleObject(10, 1, vector.NewVector(100, 100))
 		c2 := NewCircleObject(10, 1, vector.NewVector(100, 119))
-		res, _ := DetectCollision(&c1, &c2)
+		res, _, _ := DetectCollision(&c1, &c2)
 		So(res, ShouldBeTrue)
 	})
 
 	Convey("Circles not colliding", t, func() {
 		c1 := NewCircleObject(10, 1, vector.NewVector(100, 100))
 		c2 := NewCircleObject(10, 1, vector.NewVector(100, 121))
-		res, _ := DetectCollision(&c1, &c2)
+		res, _, _ := DetectCollision(&c1, &c2)
 		So(res, ShouldBeFalse)
 	})
 }
@@ -27,14 +28,14 @@ func TestBBCollisions(t *testing.T) {
 	Convey("Should detect boxes colliding", t, func() {
 		r1 := NewRectangleObject(10, 20, 1, vector.NewVector(10, 10))
 		r2 := NewRectangleObject(15, 20, 1, vector.NewVector(15, 20))
-		res, _ := DetectCollision(&r1, &r2)
+		res, _, _ := DetectCollision(&r1, &r2)
 		So(res, ShouldBeTrue)
 	})
 
 	Convey("Boxes not colliding", t, func() {
 		r1 := NewRectangleObject(10, 20, 1, vector.NewVector(10, 10))
 		r2 := NewRectangleObject(15, 20, 1, vector.NewVector(10, 31))
-		res, _ := DetectCollision(&r1, &r2)
+		res, _, _ := DetectCollision(&r1, &r2)
 		So(res, ShouldBeFalse)
 	})
 }
@@ -43,22 +44,22 @@ func TestCircleBBCollisions(t *testing.T) {
 	Convey("Should calc that point is in box", t, func() {
 		b := NewRectangleObject(10, 20, 1, vector.NewVector(10, 10))
 		v := vector.NewVector(10, 20)
-		res := isPointInsideBox(v, &b)
+		res := b.ContainsPoint(v)
 		So(res, ShouldBeTrue)
 
 		v = vector.NewVector(20, 20)
-		res = isPointInsideBox(v, &b)
+		res = b.ContainsPoint(v)
 		So(res, ShouldBeTrue)
 	})
 
 	Convey("Should calc that point is NOT in box", t, func() {
 		b := NewRectangleObject(10, 20, 1, vector.NewVector(10, 10))
 		v := vector.NewVector(9, 9)
-		res := isPointInsideBox(v, &b)
+		res := b.ContainsPoint(v)
 		So(res, ShouldBeFalse)
 
 		v = vector.NewVector(31, 9)
-		res = isPointInsideBox(v, &b)
+		res = b.ContainsPoint(v)
 		So(res, ShouldBeFalse)
 	})
 
@@ -85,9 +86,9 @@ func TestCircleBBCollisions(t *testing.T) {
 		b1 := NewRectangleObject(10, 10, 1, vector.NewVector(0, 8))
 		b2 := NewRectangleObject(10, 10, 1, vector.NewVector(8, 2))
 
-		res, _ := DetectCollision(&c, &b1)
+		res, _, _ := DetectCollision(&c, &b1)
 		So(res, ShouldBeTrue)
-		res, _ = DetectCollision(&c, &b2)
+		res, _, _ = DetectCollision(&c, &b2)
 		So(res, ShouldBeTrue)
 	})
 
@@ -96,9 +97,190 @@ func TestCircleBBCollisions(t *testing.T) {
 		b1 := NewRectangleObject(10, 10, 1, vector.NewVector(10, 10))
 		b2 := NewRectangleObject(10, 10, 1, vector.NewVector(12, 10))
 
-		res, _ := DetectCollision(&c, &b1)
+		res, _, _ := DetectCollision(&c, &b1)
 		So(res, ShouldBeFalse)
-		res, _ = DetectCollision(&c, &b2)
+		res, _, _ = DetectCollision(&c, &b2)
 		So(res, ShouldBeFalse)
 	})
 }
+
+func TestCollisionManifolds(t *testing.T) {
+	Convey("Should describe circles overlapping", t, func() {
+		c1 := NewCircleObject(10, 1, vector.NewVector(100, 100))
+		c2 := NewCircleObject(10, 1, vector.NewVector(100, 116))
+		_, m, _ := DetectCollision(&c1, &c2)
+		So(m.Normal.GetVals()[0], ShouldEqual, 0)
+		So(m.Normal.GetVals()[1], ShouldEqual, 1)
+		So(m.Depth, ShouldEqual, 4)
+		So(len(m.Points), ShouldEqual, 1)
+		So(m.Points[0].GetVals()[1], ShouldEqual, 108)
+	})
+
+	Convey("Should point the normal from the first object to the second", t, func() {
+		c := NewCircleObject(5, 1, vector.NewVector(15, 24))
+		b := NewRectangleObject(10, 10, 1, vector.NewVector(10, 10))
+
+		_, m, _ := DetectCollision(&c, &b)
+		So(m.Normal.GetVals()[0], ShouldEqual, 0)
+		So(m.Normal.GetVals()[1], ShouldEqual, -1)
+		So(m.Depth, ShouldEqual, 1)
+		So(m.Points[0].GetVals()[0], ShouldEqual, 15)
+		So(m.Points[0].GetVals()[1], ShouldEqual, 20)
+
+		_, m, _ = DetectCollision(&b, &c)
+		So(m.Normal.GetVals()[1], ShouldEqual, 1)
+		So(m.Depth, ShouldEqual, 1)
+	})
+
+	Convey("Should use a unit normal for corner contacts", t, func() {
+		c := NewCircleObject(5, 1, vector.NewVector(23, 24))
+		b := NewRectangleObject(10, 10, 1, vector.NewVector(10, 10))
+		_, m, _ := DetectCollision(&c, &b)
+		So(m.Normal.Magnitude(), ShouldAlmostEqual, 1)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, -0.6)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -0.8)
+		So(m.Depth, ShouldAlmostEqual, 0)
+	})
+
+	Convey("Should push a circle whose centre is inside a box out of the nearest face", t, func() {
+		c := NewCircleObject(5, 1, vector.NewVector(18, 15))
+		b := NewRectangleObject(10, 10, 1, vector.NewVector(10, 10))
+		_, m, _ := DetectCollision(&c, &b)
+		So(m.Normal.GetVals()[0], ShouldEqual, -1)
+		So(m.Normal.GetVals()[1], ShouldEqual, 0)
+		So(m.Depth, ShouldEqual, 7)
+		So(m.Points[0].GetVals()[0], ShouldEqual, 20)
+		So(m.Points[0].GetVals()[1], ShouldEqual, 15)
+	})
+}
+
+func TestBBMinimumTranslation(t *testing.T) {
+	cases := []struct {
+		name     string
+		pos1     vector.Vector
+		dim1     vector.Vector
+		pos2     vector.Vector
+		dim2     vector.Vector
+		collided bool
+		normal   []float64
+		depth    float64
+		points   [][]float64
+	}{
+		{
+			name: "resting on top edge",
+			pos1: vector.NewVector(0, 0), dim1: vector.NewVector(100, 10),
+			pos2: vector.NewVector(20, 8), dim2: vector.NewVector(10, 10),
+			collided: true, normal: []float64{0, 1}, depth: 2,
+			points: [][]float64{{20, 9}, {30, 9}},
+		},
+		{
+			name: "pushing into left edge",
+			pos1: vector.NewVector(10, 0), dim1: vector.NewVector(10, 30),
+			pos2: vector.NewVector(3, 5), dim2: vector.NewVector(10, 10),
+			collided: true, normal: []float64{-1, 0}, depth: 3,
+			points: [][]float64{{11.5, 5}, {11.5, 15}},
+		},
+		{
+			name: "overlapping corners",
+			pos1: vector.NewVector(0, 0), dim1: vector.NewVector(10, 10),
+			pos2: vector.NewVector(9, 6), dim2: vector.NewVector(10, 10),
+			collided: true, normal: []float64{1, 0}, depth: 1,
+			points: [][]float64{{9.5, 6}, {9.5, 10}},
+		},
+		{
+			name: "touching corners",
+			pos1: vector.NewVector(0, 0), dim1: vector.NewVector(10, 10),
+			pos2: vector.NewVector(10, 10), dim2: vector.NewVector(10, 10),
+			collided: true, normal: []float64{1, 0}, depth: 0,
+			points: [][]float64{{10, 10}},
+		},
+		{
+			name: "contained near the bottom",
+			pos1: vector.NewVector(0, 0), dim1: vector.NewVector(100, 100),
+			pos2: vector.NewVector(40, 5), dim2: vector.NewVector(10, 10),
+			collided: true, normal: []float64{0, -1}, depth: 15,
+			points: [][]float64{{40, 7.5}, {50, 7.5}},
+		},
+		{
+			name: "containing",
+			pos1: vector.NewVector(40, 80), dim1: vector.NewVector(10, 10),
+			pos2: vector.NewVector(0, 0), dim2: vector.NewVector(100, 100),
+			collided: true, normal: []float64{0, -1}, depth: 20,
+			points: [][]float64{{40, 90}, {50, 90}},
+		},
+		{
+			name: "apart",
+			pos1: vector.NewVector(0, 0), dim1: vector.NewVector(10, 10),
+			pos2: vector.NewVector(5, 11), dim2: vector.NewVector(10, 10),
+			collided: false,
+		},
+	}
+
+	for _, tc := range cases {
+		Convey("Should find the minimum translation when "+tc.name, t, func() {
+			d1 := tc.dim1.GetVals()
+			d2 := tc.dim2.GetVals()
+			r1 := NewRectangleObject(d1[0], d1[1], 1, tc.pos1)
+			r2 := NewRectangleObject(d2[0], d2[1], 1, tc.pos2)
+
+			collided, m, _ := DetectCollision(&r1, &r2)
+			So(collided, ShouldEqual, tc.collided)
+			if !tc.collided {
+				return
+			}
+
+			So(m.Normal.GetVals(), ShouldResemble, tc.normal)
+			So(m.Depth, ShouldEqual, tc.depth)
+			So(len(m.Points), ShouldEqual, len(tc.points))
+			for i, p := range tc.points {
+				So(m.Points[i].GetVals(), ShouldResemble, p)
+			}
+
+			// moving the second box along the normal by the depth separates them
+			r2.AdjustPosition(m.Normal.Scale(m.Depth + 0.01))
+			collided, _, _ = DetectCollision(&r1, &r2)
+			So(collided, ShouldBeFalse)
+		})
+	}
+}
+
+func TestManifoldsForEveryPair(t *testing.T) {
+	Convey("Should fill in the manifold for every pair of built-in shapes", t, func() {
+		circle := NewCircleObject(5, 1, vector.NewVector(15, 15))
+		box := NewRectangleObject(10, 10, 1, vector.NewVector(11, 11))
+		crate := NewRectangleObject(10, 10, 1, vector.NewVector(14, 8))
+		rotated := NewRectangleObject(10, 10, 1, vector.NewVector(9, 9))
+		rotated.angle = math.Pi / 6
+		polygon, _ := NewPolygonObject([]vector.Vector{
+			vector.NewVector(0, 0), vector.NewVector(10, 0), vector.NewVector(5, 10),
+		}, 1, vector.NewVector(12, 8))
+		capsule := NewCapsuleObject(10, 2, 1, vector.NewVector(16, 15))
+		segment := NewSegmentObject(vector.NewVector(8, 14), vector.NewVector(22, 16))
+		chain, _ := NewChainObject([]vector.Vector{
+			vector.NewVector(0, 14), vector.NewVector(30, 16), vector.NewVector(40, 30),
+		}, false)
+		shapes := map[string]Collider{
+			"circle": &circle, "box": &box, "crate": &crate, "rotated box": &rotated, "polygon": &polygon,
+			"capsule": &capsule, "segment": &segment, "chain": &chain,
+		}
+
+		for firstName, first := range shapes {
+			for secondName, second := range shapes {
+				if first == second {
+					continue
+				}
+				collided, m, err := DetectCollision(first, second)
+				if err != nil {
+					// two static shapes, such as chains, are never tested against each other
+					continue
+				}
+				Convey(firstName+" against "+secondName, func() {
+					So(collided, ShouldBeTrue)
+					So(m.Normal.Magnitude(), ShouldAlmostEqual, 1)
+					So(m.Depth, ShouldBeGreaterThan, 0)
+					So(len(m.Points), ShouldBeGreaterThan, 0)
+				})
+			}
+		}
+	})
+}
@@ -1,25 +1,73 @@
 package object
 
 import (
+	"ganymede/integrator"
 	"ganymede/vector"
 )
 
 // Object interface is implemented by all objects
 type Object interface {
+	GetKind() BodyKind
 	GetMass() float64
+	GetInverseMass() float64
 	GetPosition() vector.Vector
+	GetVelocity() vector.Vector
+	SetVelocity(vector.Vector)
 	GetAcceleration() vector.Vector
 	ApplyAcceleration(vector.Vector)
+	ApplyForce(vector.Vector)
+	ApplyImpulse(vector.Vector)
 	RotateAcceleration(float64)
+	GetCentre() vector.Vector
+	GetAngle() float64
+	GetAngularVelocity() float64
+	SetAngularVelocity(float64)
+	GetInverseInertia() float64
+	ApplyTorque(float64)
+	ApplyForceAtPoint(force, point vector.Vector)
+	ApplyImpulseAtPoint(impulse, point vector.Vector)
+	GetVelocityAtPoint(point vector.Vector) vector.Vector
+	GetRestitution() float64
+	GetFriction() float64
+	Integrate(float64)
 }
 
-// NewGenericObject creates a generic object
-func NewGenericObject(mass float64, position vector.Vector, collisionType collisionType) GenericObject {
-	acceleration := initAcceleration(position)
-	return GenericObject{mass, position, collisionType, acceleration}
+const (
+	defaultRestitution = 0
+	defaultFriction    = 0.2
+)
+
+// NewGenericObject creates a generic object.
+// A mass of 0 means the object has infinite mass, so it is created as a static body.
+// Any other mass creates a dynamic body.
+func NewGenericObject(mass float64, position vector.Vector, collisionType CollisionType) GenericObject {
+	kind := Dynamic
+	if mass == 0 {
+		kind = Static
+	}
+	return GenericObject{
+		kind:          kind,
+		mass:          mass,
+		invMass:       inverseMass(mass),
+		position:      position,
+		centreOffset:  zeroVector(position),
+		collisionType: collisionType,
+		velocity:      zeroVector(position),
+		acceleration:  zeroVector(position),
+		restitution:   defaultRestitution,
+		friction:      defaultFriction,
+	}
 }
 
-func initAcceleration(v vector.Vector) vector.Vector {
+func inverseMass(mass float64) float64 {
+	if mass == 0 {
+		return 0
+	}
+	return 1 / mass
+}
+
+// zeroVector returns a zero vector with the same dimensions as v
+func zeroVector(v vector.Vector) vector.Vector {
 	a := []float64{}
 	for range v.GetVals() {
 		a = append(a, 0)
@@ -29,10 +77,55 @@ func initAcceleration(v vector.Vector) vector.Vector {
 
 // GenericObject is a generic object implementing the object interface
 type GenericObject struct {
+	kind          BodyKind
 	mass          float64
+	invMass       float64
 	position      vector.Vector
-	collisionType collisionType
+	centreOffset  vector.Vector
+	collisionType CollisionType
+	velocity      vector.Vector
 	acceleration  vector.Vector
+	integrator    integrator.Integrator
+
+	inertia             float64
+	invInertia          float64
+	angle               float64
+	angularVelocity     float64
+	angularAcceleration float64
+
+	restitution float64
+	friction    float64
+
+	bullet bool
+}
+
+// GetKind returns whether the object is dynamic, static or kinematic
+func (o *GenericObject) GetKind() BodyKind {
+	return o.kind
+}
+
+// SetKind changes whether the object is dynamic, static or kinematic.
+// Making an object static stops it.
+func (o *GenericObject) SetKind(kind BodyKind) {
+	o.kind = kind
+	if kind == Static {
+		o.velocity = zeroVector(o.velocity)
+		o.angularVelocity = 0
+	}
+	o.acceleration = zeroVector(o.acceleration)
+	o.angularAcceleration = 0
+}
+
+// IsBullet returns true if the object is swept for collisions between steps
+func (o *GenericObject) IsBullet() bool {
+	return o.bullet
+}
+
+// SetBullet makes a world sweep the object along its path each step and stop it at the first body in the way,
+// so that it can't pass through thin walls however fast it moves. This costs more than ordinary collision detection,
+// so is best kept for small, fast objects like bullets.
+func (o *GenericObject) SetBullet(bullet bool) {
+	o.bullet = bullet
 }
 
 // GetMass returns the mass of the object
@@ -40,18 +133,137 @@ func (o *GenericObject) GetMass() float64 {
 	return o.mass
 }
 
+// GetRestitution returns how bouncy the object is
+func (o *GenericObject) GetRestitution() float64 {
+	return o.restitution
+}
+
+// SetRestitution sets how bouncy the object is.
+// 0 stops dead on contact and 1 bounces back with the same speed.
+func (o *GenericObject) SetRestitution(restitution float64) {
+	o.restitution = restitution
+}
+
+// GetFriction returns the coefficient of friction of the object's surface
+func (o *GenericObject) GetFriction() float64 {
+	return o.friction
+}
+
+// SetFriction sets the coefficient of friction of the object's surface.
+// 0 is frictionless.
+func (o *GenericObject) SetFriction(friction float64) {
+	o.friction = friction
+}
+
+// GetInverseMass returns 1 / mass, or 0 if the object has infinite mass.
+// Static and kinematic objects always have infinite mass.
+func (o *GenericObject) GetInverseMass() float64 {
+	if o.kind != Dynamic {
+		return 0
+	}
+	return o.invMass
+}
+
+// ApplyForce applies a force, accounting for the mass of the object.
+// The resulting acceleration accumulates until the next call to Integrate.
+// Only dynamic objects are affected.
+func (o *GenericObject) ApplyForce(force vector.Vector) {
+	o.acceleration = o.acceleration.Add(force.Scale(o.GetInverseMass()))
+}
+
+// ApplyImpulse changes the velocity immediately, accounting for the mass of the object.
+// This is useful for instantaneous events like collisions, jumps and explosions.
+// Only dynamic objects are affected.
+func (o *GenericObject) ApplyImpulse(impulse vector.Vector) {
+	o.velocity = o.velocity.Add(impulse.Scale(o.GetInverseMass()))
+}
+
 // ApplyAcceleration allows you to apply acceleration without factoring in the mass.
 // This might be useful for player interaction or impulse resolution.
+// The acceleration accumulates until the next call to Integrate.
+// Only dynamic objects are affected.
 func (o *GenericObject) ApplyAcceleration(acceleration vector.Vector) {
+	if o.kind != Dynamic {
+		return
+	}
 	o.acceleration = o.acceleration.Add(acceleration)
-	o.move()
 }
 
 // RotateAcceleration allows you to change the direction of the acceleration
 // without changing its magnitude.
 func (o *GenericObject) RotateAcceleration(radians float64) {
 	o.acceleration = o.acceleration.RotateAboutTail(radians)
-	o.move()
+}
+
+// GetVelocity returns the velocity vector
+func (o *GenericObject) GetVelocity() vector.Vector {
+	return o.velocity
+}
+
+// SetVelocity replaces the velocity of the object.
+// Static objects ignore this as they never move.
+func (o *GenericObject) SetVelocity(v vector.Vector) {
+	if o.kind == Static {
+		return
+	}
+	o.velocity = v
+}
+
+// SetIntegrator chooses the integration scheme used to advance this object.
+// It takes precedence over the scheme of any world the object is in.
+func (o *GenericObject) SetIntegrator(in integrator.Integrator) {
+	o.integrator = in
+}
+
+// GetIntegrator returns the integration scheme chosen for this object, or nil if none was chosen
+func (o *GenericObject) GetIntegrator() integrator.Integrator {
+	return o.integrator
+}
+
+// Integrate advances the object by the timestep dt using the accumulated acceleration.
+// The object's integrator is used, or semi-implicit Euler if none was chosen,
+// so the trajectory doesn't depend on how often the object is stepped.
+// The accumulated acceleration is cleared afterwards.
+func (o *GenericObject) Integrate(dt float64) {
+	in := o.integrator
+	if in == nil {
+		in = integrator.SemiImplicitEuler{}
+	}
+	o.IntegrateWith(in, dt, nil)
+}
+
+// IntegrateWith advances the object by the timestep dt using the integrator provided.
+// The field, if not nil, adds an acceleration that depends on the object's state,
+// and is evaluated at every intermediate state the integrator visits.
+// The accumulated acceleration is cleared afterwards.
+// Rotation is always advanced with semi-implicit Euler.
+// Static objects don't move, and kinematic objects move at their velocity ignoring any acceleration.
+func (o *GenericObject) IntegrateWith(in integrator.Integrator, dt float64, field integrator.AccelerationFunc) {
+	switch o.kind {
+	case Static:
+		return
+	case Kinematic:
+		o.position = o.position.Add(o.velocity.Scale(dt))
+		o.angle += o.angularVelocity * dt
+		return
+	}
+
+	o.angularVelocity += o.angularAcceleration * dt
+	o.angle += o.angularVelocity * dt
+	o.angularAcceleration = 0
+
+	applied := o.acceleration
+	acceleration := func(s integrator.State) vector.Vector {
+		if field == nil {
+			return applied
+		}
+		return applied.Add(field(s))
+	}
+
+	s := in.Integrate(integrator.State{Position: o.position, Velocity: o.velocity}, acceleration, dt)
+	o.position = s.Position
+	o.velocity = s.Velocity
+	o.acceleration = zeroVector(o.acceleration)
 }
 
 // AdjustPosition changes an objects position without creating acceleration.
@@ -62,10 +274,6 @@ func (o *GenericObject) AdjustPosition(v vector.Vector) {
 	o.position = o.position.Add(v)
 }
 
-func (o *GenericObject) move() {
-	o.position = o.position.Add(o.acceleration)
-}
-
 // GetPosition returns the position of the object as a vector
 func (o *GenericObject) GetPosition() vector.Vector {
 	return o.position
@@ -78,20 +286,12 @@ func (o *GenericObject) DetectCollision(v vector.Vector) bool {
 }
 
 // GetCollisionType returns the objects collision type.
-// Implements collision.collider interface
-func (o *GenericObject) GetCollisionType() collisionType {
+// Implements collision.Collider interface
+func (o *GenericObject) GetCollisionType() CollisionType {
 	return o.collisionType
 }
 
-// GetAcceleration returns the acceleration vector
+// GetAcceleration returns the acceleration accumulated since the last step
 func (o *GenericObject) GetAcceleration() vector.Vector {
 	return o.acceleration
 }
-
-// CollisionOverlapCorrection corrects overlap between colliding objects
-func (o *GenericObject) CollisionOverlapCorrection(collisionNormal, objectDimensions vector.Vector) {
-	collisionNormalUnit := collisionNormal.AsUnitVector()
-	desiredResetDistance := objectDimensions.Multiply(collisionNormalUnit)
-	adjustment := desiredResetDistance.Subtract(collisionNormal)
-	o.AdjustPosition(adjustment)
-}
@@ -0,0 +1,118 @@
+package object
+
+import (
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIntegrate(t *testing.T) {
+	Convey("Should keep applied acceleration until integrated", t, func() {
+		c := NewCircleObject(10, 1, vector.NewVector(0, 0))
+		c.ApplyAcceleration(vector.NewVector(2, 0))
+		So(c.GetPosition().GetVals()[0], ShouldEqual, 0)
+
+		c.Integrate(0.5)
+		So(c.GetVelocity().GetVals()[0], ShouldEqual, 1)
+		So(c.GetPosition().GetVals()[0], ShouldEqual, 0.5)
+		So(c.GetAcceleration().GetVals()[0], ShouldEqual, 0)
+	})
+
+	Convey("Should keep moving with its velocity", t, func() {
+		c := NewCircleObject(10, 1, vector.NewVector(0, 0))
+		c.SetVelocity(vector.NewVector(3, -4))
+		c.Integrate(2)
+		So(c.GetPosition().GetVals()[0], ShouldEqual, 6)
+		So(c.GetPosition().GetVals()[1], ShouldEqual, -8)
+	})
+
+	Convey("Should follow the same trajectory at different frame rates", t, func() {
+		gravity := vector.NewVector(0, -10)
+		fall := func(frameRate int) vector.Vector {
+			c := NewCircleObject(10, 1, vector.NewVector(0, 0))
+			c.SetVelocity(vector.NewVector(5, 20))
+			for i := 0; i < 2*frameRate; i++ {
+				c.ApplyAcceleration(gravity)
+				c.Integrate(1 / float64(frameRate))
+			}
+			return c.GetPosition()
+		}
+
+		at30 := fall(30).GetVals()
+		at60 := fall(60).GetVals()
+		So(at30[0], ShouldAlmostEqual, 10, 1e-9)
+		So(at60[0], ShouldAlmostEqual, 10, 1e-9)
+		So(at30[1], ShouldAlmostEqual, 20, 0.5)
+		So(at60[1], ShouldAlmostEqual, at30[1], 0.5)
+	})
+}
+
+func TestMassAwareForces(t *testing.T) {
+	Convey("Should accelerate lighter objects more under the same force", t, func() {
+		ball := NewCircleObject(10, 1, vector.NewVector(0, 0))
+		crate := NewRectangleObject(10, 10, 100, vector.NewVector(0, 0))
+		wind := vector.NewVector(100, 0)
+
+		ball.ApplyForce(wind)
+		crate.ApplyForce(wind)
+		So(ball.GetAcceleration().GetVals()[0], ShouldEqual, 100)
+		So(crate.GetAcceleration().GetVals()[0], ShouldEqual, 1)
+
+		ball.Integrate(1)
+		crate.Integrate(1)
+		So(ball.GetVelocity().GetVals()[0], ShouldEqual, 100)
+		So(crate.GetVelocity().GetVals()[0], ShouldEqual, 1)
+	})
+
+	Convey("Should change velocity immediately with an impulse", t, func() {
+		ball := NewCircleObject(10, 2, vector.NewVector(0, 0))
+		ball.ApplyImpulse(vector.NewVector(4, -6))
+		So(ball.GetVelocity().GetVals()[0], ShouldEqual, 2)
+		So(ball.GetVelocity().GetVals()[1], ShouldEqual, -3)
+		So(ball.GetPosition().GetVals()[0], ShouldEqual, 0)
+	})
+
+	Convey("Should treat a mass of 0 as infinite", t, func() {
+		wall := NewRectangleObject(50, 400, 0, vector.NewVector(0, 0))
+		So(wall.GetInverseMass(), ShouldEqual, 0)
+
+		wall.ApplyForce(vector.NewVector(1000, 0))
+		wall.ApplyImpulse(vector.NewVector(1000, 0))
+		wall.Integrate(1)
+		So(wall.GetVelocity().GetVals()[0], ShouldEqual, 0)
+		So(wall.GetPosition().GetVals()[0], ShouldEqual, 0)
+	})
+}
+
+func TestBodyKinds(t *testing.T) {
+	Convey("Should create objects without mass as static", t, func() {
+		wall := NewRectangleObject(50, 400, 0, vector.NewVector(0, 0))
+		ball := NewCircleObject(10, 1, vector.NewVector(0, 0))
+		So(wall.GetKind(), ShouldEqual, Static)
+		So(ball.GetKind(), ShouldEqual, Dynamic)
+	})
+
+	Convey("Should never move static objects", t, func() {
+		crate := NewRectangleObject(10, 10, 5, vector.NewVector(0, 0))
+		crate.SetKind(Static)
+		crate.SetVelocity(vector.NewVector(10, 0))
+		crate.ApplyAcceleration(vector.NewVector(10, 0))
+		crate.ApplyImpulse(vector.NewVector(10, 0))
+		crate.Integrate(1)
+		So(crate.GetInverseMass(), ShouldEqual, 0)
+		So(crate.GetPosition().GetVals()[0], ShouldEqual, 0)
+	})
+
+	Convey("Should move kinematic objects only by their velocity", t, func() {
+		lift := NewRectangleObject(100, 10, 5, vector.NewVector(0, 0))
+		lift.SetKind(Kinematic)
+		lift.SetVelocity(vector.NewVector(0, 2))
+		lift.ApplyForce(vector.NewVector(0, -1000))
+		lift.ApplyImpulse(vector.NewVector(0, -1000))
+		lift.Integrate(3)
+		So(lift.GetInverseMass(), ShouldEqual, 0)
+		So(lift.GetVelocity().GetVals()[1], ShouldEqual, 2)
+		So(lift.GetPosition().GetVals()[1], ShouldEqual, 6)
+	})
+}
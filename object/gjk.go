@@ -0,0 +1,180 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+)
+
+// Convex is implemented by convex shapes, which can collide with any other convex shape through GJK
+// without a dedicated test for the pair
+type Convex interface {
+	// Support returns the point of the shape furthest in the direction, in world space
+	Support(direction vector.Vector) vector.Vector
+}
+
+const (
+	// maxGJKIterations bounds the search for a simplex enclosing the origin
+	maxGJKIterations = 32
+	// maxEPAIterations bounds the expansion of the polytope, which only converges gradually on curved shapes
+	maxEPAIterations = 64
+	// epaTolerance is how close the polytope must come to the boundary of the Minkowski difference
+	epaTolerance = 1e-6
+)
+
+// minkowskiPoint is a point on the boundary of the Minkowski difference a - b,
+// along with the points of each shape it came from
+type minkowskiPoint struct {
+	point, a, b vector.Vector
+}
+
+func minkowskiSupport(a, b Convex, direction vector.Vector) minkowskiPoint {
+	onA := a.Support(direction)
+	onB := b.Support(direction.Scale(-1))
+	return minkowskiPoint{onA.Subtract(onB), onA, onB}
+}
+
+// convexAndConvex tests two convex shapes with GJK, and if they overlap
+// finds the penetration normal and depth with EPA.
+// The manifold has a single point, midway between the deepest points of each shape.
+func convexAndConvex(a, b Convex) (bool, Manifold) {
+	simplex, collided := gjk(a, b)
+	if !collided {
+		return false, Manifold{}
+	}
+	return true, epa(a, b, simplex)
+}
+
+// gjk searches for a triangle of points of the Minkowski difference a - b that encloses the origin.
+// The shapes overlap if and only if it finds one.
+func gjk(a, b Convex) ([]minkowskiPoint, bool) {
+	s := minkowskiSupport(a, b, vector.NewVector(1, 0))
+	simplex := []minkowskiPoint{s}
+	direction := s.point.Scale(-1)
+
+	for i := 0; i < maxGJKIterations; i++ {
+		if direction.Magnitude() == 0 {
+			// the origin is on the simplex, so the shapes only touch
+			return nil, false
+		}
+
+		s = minkowskiSupport(a, b, direction)
+		if s.point.DotProduct(direction) <= 0 {
+			// nothing in the difference reaches past the origin
+			return nil, false
+		}
+
+		simplex = append(simplex, s)
+		var enclosed bool
+		simplex, direction, enclosed = nearestSimplex(simplex)
+		if enclosed {
+			return simplex, true
+		}
+	}
+	return nil, false
+}
+
+// nearestSimplex reduces the simplex to the feature nearest the origin,
+// and returns the direction from that feature towards the origin.
+// The newest point is last.
+func nearestSimplex(simplex []minkowskiPoint) ([]minkowskiPoint, vector.Vector, bool) {
+	newest := simplex[len(simplex)-1]
+	toOrigin := newest.point.Scale(-1)
+
+	if len(simplex) == 2 {
+		edge := simplex[0].point.Subtract(newest.point)
+		if edge.DotProduct(toOrigin) <= 0 {
+			return []minkowskiPoint{newest}, toOrigin, false
+		}
+		return simplex, perpendicularTowards(edge, toOrigin), false
+	}
+
+	// the origin is known to be beyond the oldest edge, so check the two edges to the newest point
+	toB := simplex[1].point.Subtract(newest.point)
+	toC := simplex[0].point.Subtract(newest.point)
+	perpB := perpendicularTowards(toB, toC).Scale(-1)
+	if perpB.DotProduct(toOrigin) > 0 {
+		return []minkowskiPoint{simplex[1], newest}, perpB, false
+	}
+	perpC := perpendicularTowards(toC, toB).Scale(-1)
+	if perpC.DotProduct(toOrigin) > 0 {
+		return []minkowskiPoint{simplex[0], newest}, perpC, false
+	}
+	return simplex, toOrigin, true
+}
+
+// perpendicularTowards returns a vector perpendicular to v on the side of towards
+func perpendicularTowards(v, towards vector.Vector) vector.Vector {
+	perp := v.Perpendicular()
+	if perp.DotProduct(towards) < 0 {
+		return perp.Scale(-1)
+	}
+	return perp
+}
+
+// epa expands the triangle found by GJK towards the boundary of the Minkowski difference.
+// The edge of the boundary nearest the origin gives the normal and depth,
+// and the points of each shape it came from give the contact point.
+func epa(a, b Convex, simplex []minkowskiPoint) Manifold {
+	polytope := append([]minkowskiPoint{}, simplex...)
+	if (polytope[1].point.Subtract(polytope[0].point)).PerpDotProduct(polytope[2].point.Subtract(polytope[0].point)) < 0 {
+		polytope[0], polytope[1] = polytope[1], polytope[0]
+	}
+
+	var edge int
+	var normal vector.Vector
+	var distance float64
+	for i := 0; i < maxEPAIterations; i++ {
+		edge, normal, distance = nearestEdge(polytope)
+		s := minkowskiSupport(a, b, normal)
+		if s.point.DotProduct(normal)-distance < epaTolerance {
+			break
+		}
+
+		polytope = append(polytope, minkowskiPoint{})
+		copy(polytope[edge+2:], polytope[edge+1:])
+		polytope[edge+1] = s
+	}
+
+	// the origin projects onto the nearest edge between its ends, which locates the deepest points of each shape
+	start := polytope[edge]
+	end := polytope[(edge+1)%len(polytope)]
+	along := end.point.Subtract(start.point)
+	t := 0.0
+	if lengthSq := along.DotProduct(along); lengthSq > 0 {
+		t = clamp(-start.point.DotProduct(along)/lengthSq, 0, 1)
+	}
+	onA := start.a.Add(end.a.Subtract(start.a).Scale(t))
+	onB := start.b.Add(end.b.Subtract(start.b).Scale(t))
+
+	return Manifold{Normal: normal, Depth: distance, Points: []vector.Vector{onA.Add(onB).Scale(0.5)}}
+}
+
+// nearestEdge finds the edge of the polytope, wound anti-clockwise, nearest the origin
+func nearestEdge(polytope []minkowskiPoint) (int, vector.Vector, float64) {
+	edge := 0
+	normal := vector.NewVector(0, 0)
+	distance := math.Inf(1)
+	for i, p := range polytope {
+		n := edgeNormal(p.point, polytope[(i+1)%len(polytope)].point)
+		if d := n.DotProduct(p.point); d < distance {
+			edge, normal, distance = i, n, d
+		}
+	}
+	return edge, normal, distance
+}
+
+// supportOfVertices returns the vertex furthest in the direction
+func supportOfVertices(vertices []vector.Vector, direction vector.Vector) vector.Vector {
+	best := vertices[0]
+	furthest := math.Inf(-1)
+	for _, v := range vertices {
+		if d := v.DotProduct(direction); d > furthest {
+			best, furthest = v, d
+		}
+	}
+	return best
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(v, max))
+}
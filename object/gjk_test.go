@@ -0,0 +1,104 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var ellipseCollision = NewCollisionType()
+
+// ellipse is a convex shape the package has no dedicated tests for
+type ellipse struct {
+	centre           vector.Vector
+	radiusX, radiusY float64
+}
+
+func (e ellipse) GetCollisionType() CollisionType {
+	return ellipseCollision
+}
+
+func (e ellipse) GetPosition() vector.Vector {
+	return e.centre
+}
+
+func (e ellipse) Support(direction vector.Vector) vector.Vector {
+	d := direction.GetVals()
+	scaled := vector.NewVector(d[0]*e.radiusX*e.radiusX, d[1]*e.radiusY*e.radiusY)
+	length := math.Sqrt(d[0]*d[0]*e.radiusX*e.radiusX + d[1]*d[1]*e.radiusY*e.radiusY)
+	if length == 0 {
+		return e.centre
+	}
+	return e.centre.Add(scaled.Scale(1 / length))
+}
+
+func TestGJK(t *testing.T) {
+	Convey("Should agree with the circle test", t, func() {
+		c1 := NewCircleObject(10, 1, vector.NewVector(0, 0))
+		c2 := NewCircleObject(10, 1, vector.NewVector(9, 12))
+
+		collided, m := convexAndConvex(c1, c2)
+		_, expected, _ := DetectCollision(&c1, &c2)
+		So(collided, ShouldBeTrue)
+		So(m.Depth, ShouldAlmostEqual, expected.Depth, 1e-3)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, expected.Normal.GetVals()[0], 1e-3)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, expected.Normal.GetVals()[1], 1e-3)
+		So(m.Points[0].GetVals()[0], ShouldAlmostEqual, expected.Points[0].GetVals()[0], 1e-2)
+		So(m.Points[0].GetVals()[1], ShouldAlmostEqual, expected.Points[0].GetVals()[1], 1e-2)
+
+		c2 = NewCircleObject(10, 1, vector.NewVector(12, 17))
+		collided, _ = convexAndConvex(c1, c2)
+		So(collided, ShouldBeFalse)
+	})
+
+	Convey("Should agree with the separating axis test for rotated boxes", t, func() {
+		r1 := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		r2 := NewRectangleObject(10, 10, 1, vector.NewVector(6, 9))
+		r1.SetAngle(0.3)
+		r2.SetAngle(-0.5)
+
+		collided, m := convexAndConvex(r1, r2)
+		_, expected, _ := DetectCollision(&r1, &r2)
+		So(collided, ShouldBeTrue)
+		So(m.Depth, ShouldAlmostEqual, expected.Depth, 1e-6)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, expected.Normal.GetVals()[0], 1e-6)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, expected.Normal.GetVals()[1], 1e-6)
+
+		r2.AdjustPosition(vector.NewVector(10, 10))
+		collided, _ = convexAndConvex(r1, r2)
+		So(collided, ShouldBeFalse)
+	})
+
+	Convey("Should separate concentric shapes", t, func() {
+		c := NewCircleObject(5, 1, vector.NewVector(0, 0))
+		r := NewRectangleObject(4, 4, 1, vector.NewVector(-2, -2))
+		collided, m := convexAndConvex(c, r)
+		So(collided, ShouldBeTrue)
+		So(m.Depth, ShouldAlmostEqual, 7, 1e-3)
+		So(m.Normal.Magnitude(), ShouldAlmostEqual, 1)
+	})
+
+	Convey("Should collide shapes the package has no dedicated test for", t, func() {
+		e := ellipse{vector.NewVector(0, 0), 20, 5}
+		ball := NewCircleObject(5, 1, vector.NewVector(0, 8))
+
+		collided, m, _ := DetectCollision(e, &ball)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 0, 1e-3)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1, 1e-3)
+		So(m.Depth, ShouldAlmostEqual, 2, 1e-3)
+
+		collided, m, _ = DetectCollision(&ball, e)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -1, 1e-3)
+
+		far := NewCircleObject(5, 1, vector.NewVector(24, 0))
+		So(func() { collided, _, _ = DetectCollision(e, &far) }, ShouldNotPanic)
+		So(collided, ShouldBeTrue)
+		far.AdjustPosition(vector.NewVector(2, 0))
+		collided, _, _ = DetectCollision(e, &far)
+		So(collided, ShouldBeFalse)
+	})
+}
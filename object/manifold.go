@@ -0,0 +1,30 @@
+package object
+
+import (
+	"ganymede/vector"
+)
+
+// Manifold describes how two colliding objects touch
+type Manifold struct {
+	// Normal is a unit vector pointing from the first object towards the second.
+	// Moving the second object along it by Depth separates them.
+	Normal vector.Vector
+	// Depth is how far the objects overlap along the normal
+	Depth float64
+	// Points are where the objects touch in world space. There are one or two.
+	Points []vector.Vector
+	// SubShapeA and SubShapeB are the indices of the parts of each object that touch,
+	// for objects made of several shapes such as compounds and chains. Other objects have one part, 0.
+	SubShapeA, SubShapeB int
+}
+
+// Flip returns the manifold as seen from the second object
+func (m Manifold) Flip() Manifold {
+	return Manifold{
+		Normal:    m.Normal.Scale(-1),
+		Depth:     m.Depth,
+		Points:    m.Points,
+		SubShapeA: m.SubShapeB,
+		SubShapeB: m.SubShapeA,
+	}
+}
@@ -0,0 +1,70 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+)
+
+// nearestPointOnSegment returns the point on the segment from a to b nearest to p
+func nearestPointOnSegment(p, a, b vector.Vector) vector.Vector {
+	along := b.Subtract(a)
+	lengthSq := along.DotProduct(along)
+	if lengthSq == 0 {
+		return a
+	}
+	t := clamp(p.Subtract(a).DotProduct(along)/lengthSq, 0, 1)
+	return a.Add(along.Scale(t))
+}
+
+// nearestPointsOnSegments returns the nearest points between the segment from a1 to b1
+// and the segment from a2 to b2
+func nearestPointsOnSegments(a1, b1, a2, b2 vector.Vector) (vector.Vector, vector.Vector) {
+	d1 := b1.Subtract(a1)
+	d2 := b2.Subtract(a2)
+	r := a1.Subtract(a2)
+	lengthSq1 := d1.DotProduct(d1)
+	lengthSq2 := d2.DotProduct(d2)
+	f := d2.DotProduct(r)
+
+	if lengthSq1 == 0 {
+		return a1, nearestPointOnSegment(a1, a2, b2)
+	}
+	c := d1.DotProduct(r)
+	if lengthSq2 == 0 {
+		return nearestPointOnSegment(a2, a1, b1), a2
+	}
+
+	// find where the lines come closest, then clamp to the segments.
+	// Parallel lines come closest everywhere, so start from a1.
+	b := d1.DotProduct(d2)
+	denominator := lengthSq1*lengthSq2 - b*b
+	s := 0.0
+	if denominator != 0 {
+		s = clamp((b*f-c*lengthSq2)/denominator, 0, 1)
+	}
+	t := (b*s + f) / lengthSq2
+	if t < 0 {
+		t = 0
+		s = clamp(-c/lengthSq1, 0, 1)
+	} else if t > 1 {
+		t = 1
+		s = clamp((b-c)/lengthSq1, 0, 1)
+	}
+	return a1.Add(d1.Scale(s)), a2.Add(d2.Scale(t))
+}
+
+// nearestPointsOfPolygons returns the nearest points between the outlines of two polygons
+func nearestPointsOfPolygons(vertices1, vertices2 []vector.Vector) (vector.Vector, vector.Vector) {
+	var on1, on2 vector.Vector
+	nearest := math.Inf(1)
+	for i := range vertices1 {
+		a1, b1 := vertices1[i], vertices1[(i+1)%len(vertices1)]
+		for j := range vertices2 {
+			p1, p2 := nearestPointsOnSegments(a1, b1, vertices2[j], vertices2[(j+1)%len(vertices2)])
+			if d := p2.Subtract(p1).Magnitude(); d < nearest {
+				on1, on2, nearest = p1, p2, d
+			}
+		}
+	}
+	return on1, on2
+}
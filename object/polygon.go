@@ -0,0 +1,117 @@
+package object
+
+import (
+	"errors"
+	"ganymede/vector"
+)
+
+var (
+	// ErrTooFewVertices is returned when a polygon has fewer than three vertices,
+	// or a chain has too few to make an edge
+	ErrTooFewVertices = errors.New("too few vertices for the shape")
+	// ErrNotConvex is returned when a polygon's vertices don't form a convex shape,
+	// including when they repeat or lie on a line
+	ErrNotConvex = errors.New("polygon is not convex")
+)
+
+// NewPolygonObject creates a new convex polygon.
+// The vertices are relative to the position and may be wound either way.
+// They are stored wound anti-clockwise, and the polygon rotates about its centroid.
+func NewPolygonObject(vertices []vector.Vector, mass float64, position vector.Vector) (Polygon, error) {
+	if len(vertices) < 3 {
+		return Polygon{}, ErrTooFewVertices
+	}
+
+	local := append([]vector.Vector{}, vertices...)
+	if signedArea(local) < 0 {
+		for i, j := 0, len(local)-1; i < j; i, j = i+1, j-1 {
+			local[i], local[j] = local[j], local[i]
+		}
+	}
+	if !isConvex(local) {
+		return Polygon{}, ErrNotConvex
+	}
+
+	p := Polygon{
+		vertices:      local,
+		area:          signedArea(local),
+		GenericObject: NewGenericObject(mass, position, CollisionPolygon),
+	}
+	p.centreOffset = centroid(local, p.area)
+	p.SetInertia(mass * polygonInertia(local, p.centreOffset))
+	return p, nil
+}
+
+// Polygon is an object with physical implementation for a 2D convex polygon
+type Polygon struct {
+	vertices []vector.Vector // relative to the position, wound anti-clockwise
+	area     float64
+	GenericObject
+}
+
+// GetArea returns the area enclosed by the polygon
+func (p Polygon) GetArea() float64 {
+	return p.area
+}
+
+// GetVertices returns the vertices of the polygon in world space after rotation,
+// wound anti-clockwise
+func (p Polygon) GetVertices() []vector.Vector {
+	centre := p.GetCentre()
+	world := make([]vector.Vector, len(p.vertices))
+	for i, v := range p.vertices {
+		world[i] = centre.Add(rotate(v.Subtract(p.centreOffset), p.angle))
+	}
+	return world
+}
+
+// signedArea returns the area of the polygon, positive if it is wound anti-clockwise
+func signedArea(vertices []vector.Vector) float64 {
+	area := 0.0
+	for i, v := range vertices {
+		area += v.PerpDotProduct(vertices[(i+1)%len(vertices)])
+	}
+	return area / 2
+}
+
+// isConvex reports whether every corner of the anti-clockwise polygon turns left
+func isConvex(vertices []vector.Vector) bool {
+	n := len(vertices)
+	for i := range vertices {
+		edge := vertices[(i+1)%n].Subtract(vertices[i])
+		next := vertices[(i+2)%n].Subtract(vertices[(i+1)%n])
+		if edge.PerpDotProduct(next) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// centroid returns the centre of area of the polygon
+func centroid(vertices []vector.Vector, area float64) vector.Vector {
+	c := zeroVector(vertices[0])
+	for i, v := range vertices {
+		next := vertices[(i+1)%len(vertices)]
+		c = c.Add(v.Add(next).Scale(v.PerpDotProduct(next)))
+	}
+	return c.Scale(1 / (6 * area))
+}
+
+// polygonInertia returns the moment of inertia of the polygon about the centre per unit mass,
+// summed over the triangles between the centre and each edge
+func polygonInertia(vertices []vector.Vector, centre vector.Vector) float64 {
+	numerator, denominator := 0.0, 0.0
+	for i, v := range vertices {
+		a := v.Subtract(centre)
+		b := vertices[(i+1)%len(vertices)].Subtract(centre)
+		cross := a.PerpDotProduct(b)
+		numerator += cross * (a.DotProduct(a) + a.DotProduct(b) + b.DotProduct(b))
+		denominator += cross
+	}
+	return numerator / (6 * denominator)
+}
+
+// Support returns the vertex furthest in the direction. Implements Convex
+func (p Polygon) Support(direction vector.Vector) vector.Vector {
+	return supportOfVertices(p.GetVertices(), direction)
+}
@@ -0,0 +1,157 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPolygon(t *testing.T) {
+	square := []vector.Vector{
+		vector.NewVector(0, 0),
+		vector.NewVector(2, 0),
+		vector.NewVector(2, 2),
+		vector.NewVector(0, 2),
+	}
+
+	Convey("Should compute area, centroid and inertia", t, func() {
+		p, err := NewPolygonObject(square, 3, vector.NewVector(10, 10))
+		So(err, ShouldBeNil)
+		So(p.GetArea(), ShouldAlmostEqual, 4)
+		So(p.GetCentre().GetVals()[0], ShouldAlmostEqual, 11)
+		So(p.GetCentre().GetVals()[1], ShouldAlmostEqual, 11)
+		So(p.GetInertia(), ShouldAlmostEqual, 3*(4+4)/12.0)
+
+		r := NewRectangleObject(2, 2, 3, vector.NewVector(10, 10))
+		So(p.GetInertia(), ShouldAlmostEqual, r.GetInertia())
+	})
+
+	Convey("Should find the centroid of a triangle", t, func() {
+		p, err := NewPolygonObject([]vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(3, 0),
+			vector.NewVector(0, 3),
+		}, 1, vector.NewVector(0, 0))
+		So(err, ShouldBeNil)
+		So(p.GetArea(), ShouldAlmostEqual, 4.5)
+		So(p.GetCentre().GetVals()[0], ShouldAlmostEqual, 1)
+		So(p.GetCentre().GetVals()[1], ShouldAlmostEqual, 1)
+	})
+
+	Convey("Should rewind clockwise vertices anti-clockwise", t, func() {
+		clockwise := []vector.Vector{square[3], square[2], square[1], square[0]}
+		p, err := NewPolygonObject(clockwise, 1, vector.NewVector(0, 0))
+		So(err, ShouldBeNil)
+		So(p.GetArea(), ShouldAlmostEqual, 4)
+		So(signedArea(p.GetVertices()), ShouldBeGreaterThan, 0)
+	})
+
+	Convey("Should reject invalid polygons", t, func() {
+		_, err := NewPolygonObject(square[:2], 1, vector.NewVector(0, 0))
+		So(err, ShouldEqual, ErrTooFewVertices)
+
+		dart := []vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(2, 1),
+			vector.NewVector(4, 0),
+			vector.NewVector(2, 4),
+		}
+		_, err = NewPolygonObject(dart, 1, vector.NewVector(0, 0))
+		So(err, ShouldEqual, ErrNotConvex)
+
+		line := []vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(1, 1),
+			vector.NewVector(2, 2),
+		}
+		_, err = NewPolygonObject(line, 1, vector.NewVector(0, 0))
+		So(err, ShouldEqual, ErrNotConvex)
+	})
+
+	Convey("Should rotate vertices about the centroid", t, func() {
+		p, _ := NewPolygonObject(square, 1, vector.NewVector(-1, -1))
+		p.SetAngle(math.Pi / 4)
+		vertices := p.GetVertices()
+		So(vertices[0].GetVals()[0], ShouldAlmostEqual, 0)
+		So(vertices[0].GetVals()[1], ShouldAlmostEqual, -math.Sqrt2)
+	})
+}
+
+func TestPolygonCollisions(t *testing.T) {
+	triangle := func(position vector.Vector) Polygon {
+		p, _ := NewPolygonObject([]vector.Vector{
+			vector.NewVector(0, 0),
+			vector.NewVector(4, 0),
+			vector.NewVector(2, 4),
+		}, 1, position)
+		return p
+	}
+
+	Convey("Should collide a polygon resting on a rectangle", t, func() {
+		floor := NewRectangleObject(100, 10, 0, vector.NewVector(-50, -10))
+		p := triangle(vector.NewVector(0, -0.2))
+
+		collided, m, _ := DetectCollision(&floor, &p)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.2)
+		So(len(m.Points), ShouldEqual, 2)
+
+		collided, m, _ = DetectCollision(&p, &floor)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -1)
+	})
+
+	Convey("Should collide two polygons", t, func() {
+		p1 := triangle(vector.NewVector(0, 0))
+		p2 := triangle(vector.NewVector(3.5, 0))
+		collided, m, _ := DetectCollision(&p1, &p2)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldBeGreaterThan, 0)
+
+		p3 := triangle(vector.NewVector(5, 0))
+		collided, _, _ = DetectCollision(&p1, &p3)
+		So(collided, ShouldBeFalse)
+	})
+
+	Convey("Should collide a circle against a face of a polygon", t, func() {
+		p := triangle(vector.NewVector(0, 0))
+		c := NewCircleObject(1, 1, vector.NewVector(2, -0.5))
+
+		collided, m, _ := DetectCollision(&c, &p)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 0)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.5)
+		So(m.Points[0].GetVals()[1], ShouldAlmostEqual, 0.25)
+
+		collided, m, _ = DetectCollision(&p, &c)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -1)
+	})
+
+	Convey("Should collide a circle against a vertex of a polygon", t, func() {
+		p := triangle(vector.NewVector(0, 0))
+		c := NewCircleObject(1, 1, vector.NewVector(-0.6, -0.6))
+		collided, m, _ := DetectCollision(&c, &p)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, math.Sqrt2/2)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, math.Sqrt2/2)
+		So(m.Depth, ShouldAlmostEqual, 1-0.6*math.Sqrt2)
+
+		c = NewCircleObject(1, 1, vector.NewVector(-0.8, -0.8))
+		collided, _, _ = DetectCollision(&c, &p)
+		So(collided, ShouldBeFalse)
+	})
+
+	Convey("Should push out a circle whose centre is inside a polygon", t, func() {
+		p := triangle(vector.NewVector(0, 0))
+		c := NewCircleObject(1, 1, vector.NewVector(2, 0.5))
+		collided, m, _ := DetectCollision(&c, &p)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 1.5)
+	})
+}
@@ -0,0 +1,220 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+)
+
+// RaycastHit describes where a ray first meets a shape
+type RaycastHit struct {
+	// Point is where the ray meets the shape's surface, in world space
+	Point vector.Vector
+	// Normal is the unit normal of the surface at the point, facing back towards the ray
+	Normal vector.Vector
+	// Fraction is how far along the ray the point is, from 0 at its origin to 1 at its maximum distance
+	Fraction float64
+	// SubShape is the index of the part that was hit, for objects made of several shapes such as compounds and chains.
+	// Other objects have one part, 0.
+	SubShape int
+}
+
+// Raycaster is implemented by shapes that rays can be cast against.
+// Rays that start inside a shape don't hit it, so a ray cast from inside a body only finds what is around it.
+type Raycaster interface {
+	// Raycast returns where the ray from the origin in the direction first meets the shape,
+	// if it does within maxDistance
+	Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool)
+}
+
+// castRay normalises the direction for cast, which returns how far along the ray the shape is hit and the normal there
+func castRay(origin, direction vector.Vector, maxDistance float64, cast func(direction vector.Vector) (float64, vector.Vector, bool)) (RaycastHit, bool) {
+	if direction.Magnitude() == 0 || maxDistance <= 0 {
+		return RaycastHit{}, false
+	}
+	direction = direction.Normalize()
+	distance, normal, ok := cast(direction)
+	if !ok {
+		return RaycastHit{}, false
+	}
+	return RaycastHit{
+		Point:    origin.Add(direction.Scale(distance)),
+		Normal:   normal,
+		Fraction: distance / maxDistance,
+	}, true
+}
+
+// rayCircle intersects a ray, with a unit direction, with a circle
+func rayCircle(origin, direction vector.Vector, maxDistance float64, centre vector.Vector, radius float64) (float64, vector.Vector, bool) {
+	fromCentre := origin.Subtract(centre)
+	b := fromCentre.DotProduct(direction)
+	c := fromCentre.DotProduct(fromCentre) - radius*radius
+	if c < 0 {
+		// the ray starts inside
+		return 0, vector.Vector{}, false
+	}
+	discriminant := b*b - c
+	if discriminant < 0 {
+		return 0, vector.Vector{}, false
+	}
+	distance := -b - math.Sqrt(discriminant)
+	if distance < 0 || distance > maxDistance {
+		return 0, vector.Vector{}, false
+	}
+	normal := origin.Add(direction.Scale(distance)).Subtract(centre).Normalize()
+	return distance, normal, true
+}
+
+// rayPolygon intersects a ray, with a unit direction, with a convex polygon wound anti-clockwise,
+// by clipping the ray to the inside of each edge in turn
+func rayPolygon(origin, direction vector.Vector, maxDistance float64, vertices []vector.Vector) (float64, vector.Vector, bool) {
+	enter, exit := 0.0, maxDistance
+	entered := -1
+	for i, v := range vertices {
+		normal := edgeNormal(v, vertices[(i+1)%len(vertices)])
+		// how far outside the edge the origin is, and how fast the ray approaches it
+		outside := origin.Subtract(v).DotProduct(normal)
+		approach := -direction.DotProduct(normal)
+		if approach == 0 {
+			if outside > 0 {
+				// parallel to the edge and outside it
+				return 0, vector.Vector{}, false
+			}
+			continue
+		}
+
+		crossing := outside / approach
+		if approach > 0 {
+			if crossing > enter {
+				enter, entered = crossing, i
+			}
+		} else if crossing < exit {
+			exit = crossing
+		}
+		if exit < enter {
+			return 0, vector.Vector{}, false
+		}
+	}
+	if entered < 0 {
+		// the ray starts inside
+		return 0, vector.Vector{}, false
+	}
+	return enter, edgeNormal(vertices[entered], vertices[(entered+1)%len(vertices)]), true
+}
+
+// raySegment intersects a ray, with a unit direction, with a segment, which can be hit from either side
+func raySegment(origin, direction vector.Vector, maxDistance float64, start, end vector.Vector) (float64, vector.Vector, bool) {
+	edge := end.Subtract(start)
+	denominator := direction.PerpDotProduct(edge)
+	if denominator == 0 {
+		return 0, vector.Vector{}, false
+	}
+	toStart := start.Subtract(origin)
+	distance := toStart.PerpDotProduct(edge) / denominator
+	along := toStart.PerpDotProduct(direction) / denominator
+	if distance < 0 || distance > maxDistance || along < 0 || along > 1 {
+		return 0, vector.Vector{}, false
+	}
+
+	normal := edgeNormal(start, end)
+	if normal.DotProduct(direction) > 0 {
+		normal = normal.Scale(-1)
+	}
+	return distance, normal, true
+}
+
+// rayRoundedSegment intersects a ray, with a unit direction, with a segment rounded by the radius.
+// The ray enters through one of the sides or one of the rounded ends, whichever it meets first.
+func rayRoundedSegment(origin, direction vector.Vector, maxDistance float64, start, end vector.Vector, radius float64) (float64, vector.Vector, bool) {
+	nearest := nearestPointOnSegment(origin, start, end)
+	if origin.Subtract(nearest).Magnitude() < radius {
+		return 0, vector.Vector{}, false
+	}
+
+	found := false
+	var best float64
+	var bestNormal vector.Vector
+	consider := func(distance float64, normal vector.Vector, ok bool) {
+		if ok && (!found || distance < best) {
+			found, best, bestNormal = true, distance, normal
+		}
+	}
+	consider(rayCircle(origin, direction, maxDistance, start, radius))
+	consider(rayCircle(origin, direction, maxDistance, end, radius))
+	if start.Subtract(end).Magnitude() > 0 {
+		offset := edgeNormal(start, end).Scale(radius)
+		consider(raySegment(origin, direction, maxDistance, start.Add(offset), end.Add(offset)))
+		consider(raySegment(origin, direction, maxDistance, start.Subtract(offset), end.Subtract(offset)))
+	}
+	return best, bestNormal, found
+}
+
+// Raycast returns where the ray first meets the circle. Implements Raycaster
+func (c Circle) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	return castRay(origin, direction, maxDistance, func(d vector.Vector) (float64, vector.Vector, bool) {
+		return rayCircle(origin, d, maxDistance, c.position, c.Radius)
+	})
+}
+
+// Raycast returns where the ray first meets the rectangle. Implements Raycaster
+func (r Rectangle) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	return castRay(origin, direction, maxDistance, func(d vector.Vector) (float64, vector.Vector, bool) {
+		return rayPolygon(origin, d, maxDistance, r.GetCorners())
+	})
+}
+
+// Raycast returns where the ray first meets the polygon. Implements Raycaster
+func (p Polygon) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	return castRay(origin, direction, maxDistance, func(d vector.Vector) (float64, vector.Vector, bool) {
+		return rayPolygon(origin, d, maxDistance, p.GetVertices())
+	})
+}
+
+// Raycast returns where the ray first meets the capsule. Implements Raycaster
+func (c Capsule) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	start, end := c.GetEndpoints()
+	return castRay(origin, direction, maxDistance, func(d vector.Vector) (float64, vector.Vector, bool) {
+		return rayRoundedSegment(origin, d, maxDistance, start, end, c.Radius)
+	})
+}
+
+// Raycast returns where the ray crosses the segment. Implements Raycaster
+func (s Segment) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	start, end := s.GetEndpoints()
+	return castRay(origin, direction, maxDistance, func(d vector.Vector) (float64, vector.Vector, bool) {
+		return raySegment(origin, d, maxDistance, start, end)
+	})
+}
+
+// Raycast returns where the ray first crosses the chain, with the index of the edge it crosses as the sub-shape.
+// Implements Raycaster
+func (c Chain) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	vertices := c.GetVertices()
+	var best RaycastHit
+	found := false
+	for i := 0; i < chainEdgeCount(vertices, c.closed); i++ {
+		start, end := vertices[i], vertices[(i+1)%len(vertices)]
+		hit, ok := castRay(origin, direction, maxDistance, func(d vector.Vector) (float64, vector.Vector, bool) {
+			return raySegment(origin, d, maxDistance, start, end)
+		})
+		if ok && (!found || hit.Fraction < best.Fraction) {
+			best, found = hit, true
+			best.SubShape = i
+		}
+	}
+	return best, found
+}
+
+// Raycast returns where the ray first meets any of the parts of the compound,
+// with the index of that part as the sub-shape. Implements Raycaster
+func (c Compound) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastHit, bool) {
+	var best RaycastHit
+	found := false
+	for i, p := range c.GetParts() {
+		hit, ok := p.Raycast(origin, direction, maxDistance)
+		if ok && (!found || hit.Fraction < best.Fraction) {
+			best, found = hit, true
+			best.SubShape = i
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,116 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRaycast(t *testing.T) {
+	right := vector.NewVector(1, 0)
+
+	Convey("Should hit the near side of a circle", t, func() {
+		c := NewCircleObject(10, 1, vector.NewVector(50, 0))
+		hit, ok := c.Raycast(vector.NewVector(0, 0), vector.NewVector(2, 0), 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 40)
+		So(hit.Normal.GetVals(), ShouldResemble, []float64{-1, 0})
+		So(hit.Fraction, ShouldAlmostEqual, 0.4)
+	})
+
+	Convey("Should miss shapes beyond the maximum distance, beside the ray or behind it", t, func() {
+		c := NewCircleObject(10, 1, vector.NewVector(50, 0))
+		_, ok := c.Raycast(vector.NewVector(0, 0), right, 39)
+		So(ok, ShouldBeFalse)
+		_, ok = c.Raycast(vector.NewVector(0, 11), right, 100)
+		So(ok, ShouldBeFalse)
+		_, ok = c.Raycast(vector.NewVector(0, 0), right.Scale(-1), 100)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should not hit shapes the ray starts inside", t, func() {
+		c := NewCircleObject(10, 1, vector.NewVector(0, 0))
+		_, ok := c.Raycast(vector.NewVector(0, 0), right, 100)
+		So(ok, ShouldBeFalse)
+		r := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		_, ok = r.Raycast(vector.NewVector(5, 5), right, 100)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should hit the face of a rectangle the ray enters", t, func() {
+		r := NewRectangleObject(10, 10, 1, vector.NewVector(20, -5))
+		hit, ok := r.Raycast(vector.NewVector(0, 0), right, 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 20)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+
+		hit, ok = r.Raycast(vector.NewVector(25, 50), vector.NewVector(0, -1), 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Point.GetVals()[1], ShouldAlmostEqual, 5)
+		So(hit.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+	})
+
+	Convey("Should hit the corner of a rotated rectangle", t, func() {
+		r := NewRectangleObject(10, 10, 1, vector.NewVector(20, -5))
+		r.SetAngle(math.Pi / 4)
+		hit, ok := r.Raycast(vector.NewVector(0, 0), right, 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 25-5*math.Sqrt2)
+	})
+
+	Convey("Should hit a polygon", t, func() {
+		p, _ := NewPolygonObject([]vector.Vector{
+			vector.NewVector(0, -10), vector.NewVector(10, 0), vector.NewVector(0, 10),
+		}, 1, vector.NewVector(30, 0))
+		hit, ok := p.Raycast(vector.NewVector(0, 0), right, 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 30)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+	})
+
+	Convey("Should hit a capsule on its side and on its rounded ends", t, func() {
+		c := NewCapsuleObject(20, 5, 1, vector.NewVector(50, 0))
+		hit, ok := c.Raycast(vector.NewVector(0, 0), right, 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 45)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+
+		hit, ok = c.Raycast(vector.NewVector(50, 50), vector.NewVector(0, -1), 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Point.GetVals()[1], ShouldAlmostEqual, 15)
+		So(hit.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+	})
+
+	Convey("Should hit a segment from either side", t, func() {
+		s := NewSegmentObject(vector.NewVector(10, -10), vector.NewVector(10, 10))
+		hit, ok := s.Raycast(vector.NewVector(0, 0), right, 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+
+		hit, ok = s.Raycast(vector.NewVector(20, 0), right.Scale(-1), 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, 1)
+	})
+
+	Convey("Should report the edge of a chain that is hit", t, func() {
+		c, _ := NewChainObject([]vector.Vector{
+			vector.NewVector(0, 0), vector.NewVector(10, 0), vector.NewVector(20, 10), vector.NewVector(30, 10),
+		}, false)
+		hit, ok := c.Raycast(vector.NewVector(25, 50), vector.NewVector(0, -1), 100)
+		So(ok, ShouldBeTrue)
+		So(hit.SubShape, ShouldEqual, 2)
+		So(hit.Point.GetVals()[1], ShouldAlmostEqual, 10)
+	})
+
+	Convey("Should report the part of a compound that is hit first", t, func() {
+		near := NewCircleObject(5, 1, vector.NewVector(10, 0))
+		far := NewCircleObject(5, 1, vector.NewVector(-10, 0))
+		c, _ := NewCompoundObject([]Part{&far, &near}, vector.NewVector(50, 0))
+		hit, ok := c.Raycast(vector.NewVector(100, 0), right.Scale(-1), 100)
+		So(ok, ShouldBeTrue)
+		So(hit.SubShape, ShouldEqual, 1)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 65)
+	})
+}
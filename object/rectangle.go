@@ -4,12 +4,17 @@ import (
 	"ganymede/vector"
 )
 
-// NewRectangleObject creates a new rectangle
+// NewRectangleObject creates a new rectangle.
+// The position is the corner with the smallest coordinates before the rectangle is rotated,
+// and the rectangle rotates about its centre.
 func NewRectangleObject(w float64, h float64, mass float64, position vector.Vector) Rectangle {
-	return Rectangle{
+	r := Rectangle{
 		vector.NewVector(w, h),
-		NewGenericObject(mass, position, collisionBoundingBox),
+		NewGenericObject(mass, position, CollisionBoundingBox),
 	}
+	r.centreOffset = r.dimensions.Scale(0.5)
+	r.SetInertia(mass * (w*w + h*h) / 12)
+	return r
 }
 
 // Rectangle is an object with physical implementation for a 2D rectangle
@@ -22,3 +27,14 @@ type Rectangle struct {
 func (r Rectangle) GetDimensions() vector.Vector {
 	return r.dimensions
 }
+
+// GetCorners returns the corners of the rectangle in world space after rotation,
+// wound anti-clockwise starting from the corner at the position
+func (r Rectangle) GetCorners() []vector.Vector {
+	return boundingBoxCorners(&r)
+}
+
+// Support returns the corner furthest in the direction. Implements Convex
+func (r Rectangle) Support(direction vector.Vector) vector.Vector {
+	return supportOfVertices(r.GetCorners(), direction)
+}
@@ -0,0 +1,89 @@
+package object
+
+import (
+	"ganymede/vector"
+)
+
+// Angles are in radians, and positive angles, angular velocities and torques are anti-clockwise.
+
+// SetInertia sets the moment of inertia of the object about its centre.
+// An inertia of 0 means the object can't be rotated by torque.
+func (o *GenericObject) SetInertia(inertia float64) {
+	o.inertia = inertia
+	o.invInertia = inverseMass(inertia)
+}
+
+// GetInertia returns the moment of inertia of the object about its centre
+func (o *GenericObject) GetInertia() float64 {
+	return o.inertia
+}
+
+// GetInverseInertia returns 1 / inertia, or 0 if the object can't be rotated.
+// Static and kinematic objects can never be rotated by torque.
+func (o *GenericObject) GetInverseInertia() float64 {
+	if o.kind != Dynamic {
+		return 0
+	}
+	return o.invInertia
+}
+
+// GetCentre returns the point the object rotates about, its centre of mass
+func (o *GenericObject) GetCentre() vector.Vector {
+	return o.position.Add(o.centreOffset)
+}
+
+// GetAngle returns the orientation of the object
+func (o *GenericObject) GetAngle() float64 {
+	return o.angle
+}
+
+// SetAngle replaces the orientation of the object
+func (o *GenericObject) SetAngle(radians float64) {
+	o.angle = radians
+}
+
+// GetAngularVelocity returns the rate of rotation in radians per second
+func (o *GenericObject) GetAngularVelocity() float64 {
+	return o.angularVelocity
+}
+
+// SetAngularVelocity replaces the rate of rotation.
+// Static objects ignore this as they never move.
+func (o *GenericObject) SetAngularVelocity(radiansPerSecond float64) {
+	if o.kind == Static {
+		return
+	}
+	o.angularVelocity = radiansPerSecond
+}
+
+// ApplyTorque applies a torque, accounting for the inertia of the object.
+// The resulting angular acceleration accumulates until the next call to Integrate.
+// Only dynamic objects are affected.
+func (o *GenericObject) ApplyTorque(torque float64) {
+	o.angularAcceleration += torque * o.GetInverseInertia()
+}
+
+// ApplyForceAtPoint applies a force at a point in world space.
+// A force that doesn't act through the centre of the object also applies a torque.
+func (o *GenericObject) ApplyForceAtPoint(force, point vector.Vector) {
+	o.ApplyForce(force)
+	o.ApplyTorque(o.leverArm(point).PerpDotProduct(force))
+}
+
+// ApplyImpulseAtPoint applies an impulse at a point in world space,
+// changing both the velocity and angular velocity immediately.
+func (o *GenericObject) ApplyImpulseAtPoint(impulse, point vector.Vector) {
+	o.ApplyImpulse(impulse)
+	o.angularVelocity += o.leverArm(point).PerpDotProduct(impulse) * o.GetInverseInertia()
+}
+
+// GetVelocityAtPoint returns the velocity of a point in world space attached to the object,
+// including the motion due to its rotation
+func (o *GenericObject) GetVelocityAtPoint(point vector.Vector) vector.Vector {
+	return o.velocity.Add(o.leverArm(point).Perpendicular().Scale(o.angularVelocity))
+}
+
+// leverArm returns the vector from the centre of the object to the point
+func (o *GenericObject) leverArm(point vector.Vector) vector.Vector {
+	return point.Subtract(o.GetCentre())
+}
@@ -0,0 +1,71 @@
+package object
+
+import (
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInertia(t *testing.T) {
+	Convey("Should compute inertia from mass and size", t, func() {
+		c := NewCircleObject(2, 3, vector.NewVector(0, 0))
+		So(c.GetInertia(), ShouldEqual, 6)
+		So(c.GetInverseInertia(), ShouldAlmostEqual, 1.0/6)
+
+		r := NewRectangleObject(3, 4, 12, vector.NewVector(0, 0))
+		So(r.GetInertia(), ShouldEqual, 25)
+	})
+
+	Convey("Should rotate rectangles about their centre", t, func() {
+		r := NewRectangleObject(10, 20, 1, vector.NewVector(10, 10))
+		So(r.GetCentre().GetVals()[0], ShouldEqual, 15)
+		So(r.GetCentre().GetVals()[1], ShouldEqual, 20)
+	})
+}
+
+func TestTorque(t *testing.T) {
+	Convey("Should spin up under torque", t, func() {
+		c := NewCircleObject(1, 2, vector.NewVector(0, 0))
+		c.ApplyTorque(3)
+		c.Integrate(2)
+		So(c.GetAngularVelocity(), ShouldEqual, 6)
+		So(c.GetAngle(), ShouldEqual, 12)
+	})
+
+	Convey("Should not spin from a force through the centre", t, func() {
+		r := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		r.ApplyForceAtPoint(vector.NewVector(10, 0), vector.NewVector(0, 5))
+		r.Integrate(1)
+		So(r.GetAngularVelocity(), ShouldEqual, 0)
+		So(r.GetVelocity().GetVals()[0], ShouldEqual, 10)
+	})
+
+	Convey("Should spin from an off-centre force", t, func() {
+		r := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		// pushing the top edge right turns the box clockwise
+		r.ApplyForceAtPoint(vector.NewVector(10, 0), vector.NewVector(5, 10))
+		r.Integrate(1)
+		So(r.GetAngularVelocity(), ShouldBeLessThan, 0)
+		So(r.GetVelocity().GetVals()[0], ShouldEqual, 10)
+	})
+
+	Convey("Should spin immediately from an off-centre impulse", t, func() {
+		r := NewRectangleObject(2, 2, 6, vector.NewVector(-1, -1))
+		r.ApplyImpulseAtPoint(vector.NewVector(0, 4), vector.NewVector(1, 0))
+		So(r.GetAngularVelocity(), ShouldEqual, 1)
+		So(r.GetVelocity().GetVals()[1], ShouldAlmostEqual, 4.0/6)
+
+		v := r.GetVelocityAtPoint(vector.NewVector(1, 0))
+		So(v.GetVals()[1], ShouldAlmostEqual, 4.0/6+1)
+	})
+
+	Convey("Should not spin static objects", t, func() {
+		r := NewRectangleObject(10, 10, 0, vector.NewVector(0, 0))
+		r.ApplyTorque(100)
+		r.ApplyImpulseAtPoint(vector.NewVector(0, 4), vector.NewVector(10, 0))
+		r.SetAngularVelocity(1)
+		r.Integrate(1)
+		So(r.GetAngle(), ShouldEqual, 0)
+	})
+}
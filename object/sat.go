@@ -0,0 +1,195 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+)
+
+// referenceFaceTolerance prefers the first polygon's face as the reference face
+// when both are nearly as good, so contacts don't flicker between faces
+const referenceFaceTolerance = 1e-3
+
+// rotate turns the 2D vector anti-clockwise by the angle
+func rotate(v vector.Vector, radians float64) vector.Vector {
+	return v.RotateAboutTail(-radians)
+}
+
+// edgeNormal returns the outward unit normal of the edge from v1 to v2
+// of a polygon wound anti-clockwise
+func edgeNormal(v1, v2 vector.Vector) vector.Vector {
+	return v2.Subtract(v1).Perpendicular().Scale(-1).Normalize()
+}
+
+// satPolygons tests two convex polygons, both wound anti-clockwise, with the separating axis theorem.
+// The face normal with the least overlap is the collision normal, and the points are found by
+// clipping the most opposed edge of the other polygon to that face.
+func satPolygons(vertices1, vertices2 []vector.Vector) (bool, Manifold) {
+	return satRoundedPolygons(vertices1, 0, vertices2, 0)
+}
+
+// satRoundedPolygons tests two convex polygons whose outlines are rounded by a radius.
+// A polygon can be a single segment of two vertices, which makes a capsule.
+// Between the polygons' faces the separating axis test is exact, but where a rounded corner
+// is nearest the other shape the faces understate the gap, so the nearest points are used instead.
+func satRoundedPolygons(vertices1 []vector.Vector, radius1 float64, vertices2 []vector.Vector, radius2 float64) (bool, Manifold) {
+	radius := radius1 + radius2
+	separation1, edge1 := maxSeparation(vertices1, vertices2)
+	if separation1 > radius {
+		return false, Manifold{}
+	}
+	separation2, edge2 := maxSeparation(vertices2, vertices1)
+	if separation2 > radius {
+		return false, Manifold{}
+	}
+
+	if separation := math.Max(separation1, separation2); separation > 0 && radius > 0 {
+		on1, on2 := nearestPointsOfPolygons(vertices1, vertices2)
+		between := on2.Subtract(on1)
+		distance := between.Magnitude()
+		if distance > radius {
+			return false, Manifold{}
+		}
+		if distance > separation+referenceFaceTolerance {
+			normal := between.Normalize()
+			depth := radius - distance
+			point := on1.Add(normal.Scale(radius1 - depth/2))
+			return true, Manifold{Normal: normal, Depth: depth, Points: []vector.Vector{point}}
+		}
+	}
+
+	reference, incident, edge, flip := vertices1, vertices2, edge1, false
+	referenceRadius, incidentRadius := radius1, radius2
+	if separation2 > separation1+referenceFaceTolerance {
+		reference, incident, edge, flip = vertices2, vertices1, edge2, true
+		referenceRadius, incidentRadius = radius2, radius1
+	}
+
+	m := clipToReferenceFace(reference, referenceRadius, incident, incidentRadius, edge)
+	if flip {
+		m = m.Flip()
+	}
+	return len(m.Points) > 0, m
+}
+
+// maxSeparation finds the face of polygon a that the vertices of polygon b are furthest in front of.
+// A negative separation means b overlaps that face.
+func maxSeparation(a, b []vector.Vector) (float64, int) {
+	best := math.Inf(-1)
+	bestEdge := 0
+	for i := range a {
+		normal := edgeNormal(a[i], a[(i+1)%len(a)])
+		separation := math.Inf(1)
+		for _, v := range b {
+			separation = math.Min(separation, normal.DotProduct(v.Subtract(a[i])))
+		}
+		if separation > best {
+			best, bestEdge = separation, i
+		}
+	}
+	return best, bestEdge
+}
+
+// clipToReferenceFace builds the manifold between the reference face of one polygon
+// and the edge of the incident polygon that faces it most directly
+func clipToReferenceFace(reference []vector.Vector, referenceRadius float64, incident []vector.Vector, incidentRadius float64, edge int) Manifold {
+	refStart := reference[edge]
+	refEnd := reference[(edge+1)%len(reference)]
+	normal := edgeNormal(refStart, refEnd)
+
+	incidentEdge := 0
+	mostOpposed := math.Inf(1)
+	for i := range incident {
+		d := edgeNormal(incident[i], incident[(i+1)%len(incident)]).DotProduct(normal)
+		if d < mostOpposed {
+			mostOpposed, incidentEdge = d, i
+		}
+	}
+	points := []vector.Vector{incident[incidentEdge], incident[(incidentEdge+1)%len(incident)]}
+
+	// trim the incident edge to the sides of the reference face
+	tangent := refEnd.Subtract(refStart).Normalize()
+	points = clipSegment(points, tangent.Scale(-1), -tangent.DotProduct(refStart))
+	points = clipSegment(points, tangent, tangent.DotProduct(refEnd))
+
+	// keep the points that overlap the rounded reference face, moved midway through the overlap
+	radius := referenceRadius + incidentRadius
+	m := Manifold{Normal: normal}
+	for _, p := range points {
+		separation := normal.DotProduct(p.Subtract(refStart))
+		if separation > radius {
+			continue
+		}
+		m.Points = append(m.Points, p.Subtract(normal.Scale((separation+incidentRadius-referenceRadius)/2)))
+		m.Depth = math.Max(m.Depth, radius-separation)
+	}
+	return m
+}
+
+// clipSegment keeps the part of the segment where normal · point <= offset
+func clipSegment(points []vector.Vector, normal vector.Vector, offset float64) []vector.Vector {
+	if len(points) < 2 {
+		return points
+	}
+
+	d1 := normal.DotProduct(points[0]) - offset
+	d2 := normal.DotProduct(points[1]) - offset
+	clipped := []vector.Vector{}
+	if d1 <= 0 {
+		clipped = append(clipped, points[0])
+	}
+	if d2 <= 0 {
+		clipped = append(clipped, points[1])
+	}
+	if d1*d2 < 0 {
+		t := d1 / (d1 - d2)
+		clipped = append(clipped, points[0].Add(points[1].Subtract(points[0]).Scale(t)))
+	}
+	return clipped
+}
+
+// circleAndPolygon tests a circle against a convex polygon wound anti-clockwise.
+// The circle is pushed out through the face its centre is furthest in front of,
+// or away from the nearest vertex if its centre is beyond the ends of that face.
+func circleAndPolygon(c CircleCollider, vertices []vector.Vector) (bool, Manifold) {
+	centre := c.GetPosition()
+	radius := c.GetRadius()
+
+	separation := math.Inf(-1)
+	edge := 0
+	for i := range vertices {
+		s := edgeNormal(vertices[i], vertices[(i+1)%len(vertices)]).DotProduct(centre.Subtract(vertices[i]))
+		if s > separation {
+			separation, edge = s, i
+		}
+	}
+	if separation > radius {
+		return false, Manifold{}
+	}
+
+	v1 := vertices[edge]
+	v2 := vertices[(edge+1)%len(vertices)]
+
+	// the normal points from the circle into the polygon
+	normal := edgeNormal(v1, v2).Scale(-1)
+	depth := radius - separation
+	if separation > 0 {
+		for _, end := range [][2]vector.Vector{{v1, v2}, {v2, v1}} {
+			v, other := end[0], end[1]
+			if centre.Subtract(v).DotProduct(other.Subtract(v)) > 0 {
+				continue
+			}
+
+			// the centre is beyond this end of the face, so the vertex is nearest
+			toVertex := v.Subtract(centre)
+			distance := toVertex.Magnitude()
+			if distance > radius {
+				return false, Manifold{}
+			}
+			normal, depth = toVertex.Normalize(), radius-distance
+			break
+		}
+	}
+
+	point := centre.Add(normal.Scale(radius - depth/2))
+	return true, Manifold{Normal: normal, Depth: depth, Points: []vector.Vector{point}}
+}
@@ -0,0 +1,86 @@
+package object
+
+import (
+	"ganymede/vector"
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOrientedBoxCollisions(t *testing.T) {
+	Convey("Should rotate rectangle corners about the centre", t, func() {
+		r := NewRectangleObject(2, 2, 1, vector.NewVector(-1, -1))
+		r.SetAngle(math.Pi / 4)
+		corners := r.GetCorners()
+		So(corners[0].GetVals()[0], ShouldAlmostEqual, 0)
+		So(corners[0].GetVals()[1], ShouldAlmostEqual, -math.Sqrt2)
+		So(corners[1].GetVals()[0], ShouldAlmostEqual, math.Sqrt2)
+		So(corners[1].GetVals()[1], ShouldAlmostEqual, 0)
+	})
+
+	Convey("Should separate tilted boxes whose bounds overlap", t, func() {
+		r1 := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		r2 := NewRectangleObject(10, 10, 1, vector.NewVector(12, 12))
+		r1.SetAngle(math.Pi / 4)
+		r2.SetAngle(math.Pi / 4)
+		collided, _, _ := DetectCollision(&r1, &r2)
+		So(collided, ShouldBeFalse)
+	})
+
+	Convey("Should find a corner resting on a face", t, func() {
+		floor := NewRectangleObject(100, 10, 0, vector.NewVector(-50, -10))
+		diamond := NewRectangleObject(2, 2, 1, vector.NewVector(-1, math.Sqrt2-1-0.1))
+		diamond.SetAngle(math.Pi / 4)
+
+		collided, m, _ := DetectCollision(&floor, &diamond)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, 0)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, 1)
+		So(m.Depth, ShouldAlmostEqual, 0.1)
+		So(len(m.Points), ShouldEqual, 1)
+		So(m.Points[0].GetVals()[0], ShouldAlmostEqual, 0)
+		So(m.Points[0].GetVals()[1], ShouldAlmostEqual, -0.05)
+
+		collided, m, _ = DetectCollision(&diamond, &floor)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -1)
+	})
+
+	Convey("Should find two points between parallel tilted faces", t, func() {
+		r1 := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		r2 := NewRectangleObject(10, 10, 1, vector.NewVector(0, 9))
+		angle := math.Pi / 6
+		r1.SetAngle(angle)
+		r2.SetAngle(angle)
+		// stack r2 along r1's tilted up axis
+		up := rotate(vector.NewVector(0, 1), angle)
+		r2.AdjustPosition(up.Scale(9).Subtract(vector.NewVector(0, 9)))
+
+		collided, m, _ := DetectCollision(&r1, &r2)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, up.GetVals()[0])
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, up.GetVals()[1])
+		So(m.Depth, ShouldAlmostEqual, 1)
+		So(len(m.Points), ShouldEqual, 2)
+	})
+
+	Convey("Should collide a circle with a tilted platform along its surface normal", t, func() {
+		platform := NewRectangleObject(100, 10, 0, vector.NewVector(-50, -5))
+		platform.SetAngle(math.Pi / 6)
+		up := rotate(vector.NewVector(0, 1), math.Pi/6)
+		c := NewCircleObject(2, 1, up.Scale(6))
+
+		collided, m, _ := DetectCollision(&c, &platform)
+		So(collided, ShouldBeTrue)
+		So(m.Normal.GetVals()[0], ShouldAlmostEqual, -up.GetVals()[0])
+		So(m.Normal.GetVals()[1], ShouldAlmostEqual, -up.GetVals()[1])
+		So(m.Depth, ShouldAlmostEqual, 1)
+		So(m.Points[0].GetVals()[0], ShouldAlmostEqual, up.Scale(5).GetVals()[0])
+		So(m.Points[0].GetVals()[1], ShouldAlmostEqual, up.Scale(5).GetVals()[1])
+
+		c.AdjustPosition(up.Scale(1.5))
+		collided, _, _ = DetectCollision(&c, &platform)
+		So(collided, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,34 @@
+package object
+
+import (
+	"ganymede/vector"
+)
+
+// NewSegmentObject creates a static line segment between two points, for outlining terrain.
+// The position is the start, and the segment rotates about its midpoint.
+func NewSegmentObject(start, end vector.Vector) Segment {
+	s := Segment{
+		NewGenericObject(0, start, CollisionSegment),
+	}
+	s.centreOffset = end.Subtract(start).Scale(0.5)
+	return s
+}
+
+// Segment is an object with physical implementation for a 2D line segment
+type Segment struct {
+	GenericObject
+}
+
+// GetEndpoints returns the ends of the segment in world space after rotation.
+// Implements SegmentCollider
+func (s Segment) GetEndpoints() (vector.Vector, vector.Vector) {
+	centre := s.GetCentre()
+	half := rotate(s.centreOffset, s.angle)
+	return centre.Subtract(half), centre.Add(half)
+}
+
+// Support returns the end of the segment furthest in the direction. Implements Convex
+func (s Segment) Support(direction vector.Vector) vector.Vector {
+	start, end := s.GetEndpoints()
+	return supportOfVertices([]vector.Vector{start, end}, direction)
+}
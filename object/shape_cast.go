@@ -0,0 +1,227 @@
+package object
+
+import (
+	"ganymede/vector"
+)
+
+const (
+	// maxShapeCastIterations bounds the advance of a shape cast, which only converges gradually on curved shapes
+	maxShapeCastIterations = 64
+	// shapeCastTolerance is how close a swept shape must come to another to touch it
+	shapeCastTolerance = 1e-6
+	// touchingSkin is how far a shape touching another is grown into it, so that EPA can find the normal between them
+	touchingSkin = 1e-3
+)
+
+// ShapeCastHit describes where a shape swept along a displacement first touches another
+type ShapeCastHit struct {
+	// Point is where the shapes first touch, on the surface of the shape that was hit
+	Point vector.Vector
+	// Normal is the unit normal of the surface that was hit at the point, facing back towards the swept shape
+	Normal vector.Vector
+	// Fraction is the time of impact, as how far along the displacement the shape gets before touching,
+	// from 0 if they already overlap to 1 at the end of the displacement
+	Fraction float64
+	// SubShape is the index of the part that was hit, for objects made of several shapes such as compounds and chains.
+	// Other objects have one part, 0.
+	SubShape int
+}
+
+// ShapeCast sweeps a convex shape, such as a circle or rectangle, along the displacement without turning it,
+// and returns where it first touches the target, if it does.
+// Targets are hit if they are convex, or made of convex parts like compounds and chains.
+func ShapeCast(shape Convex, displacement vector.Vector, target Collider) (ShapeCastHit, bool) {
+	var best ShapeCastHit
+	found := false
+	for i, piece := range convexPieces(target) {
+		if piece == nil {
+			continue
+		}
+		hit, ok := sweepConvex(shape, piece, displacement)
+		if ok && (!found || hit.Fraction < best.Fraction) {
+			best, found = hit, true
+			best.SubShape = i
+		}
+	}
+	return best, found
+}
+
+// convexPieces returns the convex parts of the object, by sub-shape index, with nil for any that aren't convex
+func convexPieces(o Collider) []Convex {
+	switch shape := o.(type) {
+	case CompoundCollider:
+		parts := shape.GetParts()
+		pieces := make([]Convex, len(parts))
+		for i, p := range parts {
+			pieces[i], _ = p.(Convex)
+		}
+		return pieces
+	case ChainCollider:
+		vertices := shape.GetVertices()
+		pieces := make([]Convex, chainEdgeCount(vertices, shape.IsClosed()))
+		for i := range pieces {
+			pieces[i] = segmentShape{vertices[i], vertices[(i+1)%len(vertices)]}
+		}
+		return pieces
+	case Convex:
+		return []Convex{shape}
+	}
+	return nil
+}
+
+// sweepConvex casts a ray from the origin along the displacement against the Minkowski difference b - a,
+// as the swept shape touches b when the displacement so far is in the difference.
+// The ray advances to the plane through the difference's support point each time it is found to be outside,
+// until the simplex of support points closes in on it (GJK raycast, van den Bergen).
+func sweepConvex(a, b Convex, displacement vector.Vector) (ShapeCastHit, bool) {
+	fraction := 0.0
+	x := zeroVector(displacement)
+	var normal vector.Vector
+	simplex := []minkowskiPoint{}
+	v := x.Subtract(minkowskiSupport(b, a, displacement).point)
+	var weights []float64
+
+	for i := 0; i < maxShapeCastIterations && v.Magnitude() > shapeCastTolerance; i++ {
+		p := minkowskiSupport(b, a, v)
+		w := x.Subtract(p.point)
+		if v.DotProduct(w) > 0 {
+			// the support plane separates the ray's point from the difference, so advance to the plane
+			approach := v.DotProduct(displacement)
+			if approach >= 0 {
+				return ShapeCastHit{}, false
+			}
+			fraction -= v.DotProduct(w) / approach
+			if fraction > 1 {
+				return ShapeCastHit{}, false
+			}
+			x = displacement.Scale(fraction)
+			normal = v
+		}
+
+		simplex = append(simplex, p)
+		v, simplex, weights = nearestOnSimplex(x, simplex)
+	}
+
+	if normal.GetVals() == nil {
+		return overlappingHit(a, b, displacement)
+	}
+
+	// the support points of b weighted like the nearest point of the simplex are where b is touched
+	point := zeroVector(displacement)
+	for i, p := range simplex {
+		point = point.Add(p.a.Scale(weights[i]))
+	}
+	return ShapeCastHit{Point: point, Normal: normal.Normalize(), Fraction: fraction}, true
+}
+
+// overlappingHit describes shapes that overlap or touch before they move, pushed apart along the penetration normal.
+// The swept shape is grown slightly into the other, so that shapes that only touch have a normal between
+// their nearest features too. Shapes that only touch aren't hit if the displacement moves them apart or along the surface.
+func overlappingHit(a, b Convex, displacement vector.Vector) (ShapeCastHit, bool) {
+	ok, m := convexAndConvex(grownConvex{a, touchingSkin}, b)
+	if !ok {
+		return ShapeCastHit{}, false
+	}
+	// a normal found through the skin can be slightly off, so shapes barely moving into each other are sliding
+	touching := m.Depth < 2*touchingSkin
+	if touching && m.Normal.DotProduct(displacement) <= touchingSkin*displacement.Magnitude() {
+		return ShapeCastHit{}, false
+	}
+	// the point is midway through the overlap of the grown shape, so move it back by half the skin
+	point := m.Points[0].Subtract(m.Normal.Scale(touchingSkin / 2))
+	return ShapeCastHit{Point: point, Normal: m.Normal.Scale(-1)}, true
+}
+
+// grownConvex is a convex shape grown outwards by the radius all round
+type grownConvex struct {
+	Convex
+	radius float64
+}
+
+func (g grownConvex) Support(direction vector.Vector) vector.Vector {
+	return g.Convex.Support(direction).Add(direction.Normalize().Scale(g.radius))
+}
+
+// nearestOnSimplex finds the nearest point of the simplex to x. It returns the vector from that point to x,
+// the smallest part of the simplex containing the point and the point's weights on that part.
+func nearestOnSimplex(x vector.Vector, simplex []minkowskiPoint) (vector.Vector, []minkowskiPoint, []float64) {
+	toX := make([]vector.Vector, len(simplex))
+	for i, p := range simplex {
+		toX[i] = x.Subtract(p.point)
+	}
+
+	switch len(simplex) {
+	case 1:
+		return toX[0], simplex, []float64{1}
+	case 2:
+		v, kept, weights := nearestOnEdge(toX[0], toX[1])
+		return v, pick(simplex, kept), weights
+	}
+
+	if weights, inside := barycentric(toX[0], toX[1], toX[2]); inside {
+		return zeroVector(x), simplex, weights
+	}
+	bestV := vector.Vector{}
+	var best []minkowskiPoint
+	var bestWeights []float64
+	for _, edge := range [][2]int{{0, 1}, {1, 2}, {2, 0}} {
+		v, kept, weights := nearestOnEdge(toX[edge[0]], toX[edge[1]])
+		if bestV.GetVals() == nil || v.Magnitude() < bestV.Magnitude() {
+			bestV, bestWeights = v, weights
+			best = pick([]minkowskiPoint{simplex[edge[0]], simplex[edge[1]]}, kept)
+		}
+	}
+	return bestV, best, bestWeights
+}
+
+// nearestOnEdge finds the point of the edge between p and q nearest the origin,
+// returning it, which ends of the edge it needs and its weights on them
+func nearestOnEdge(p, q vector.Vector) (vector.Vector, []int, []float64) {
+	edge := q.Subtract(p)
+	lengthSq := edge.DotProduct(edge)
+	if lengthSq == 0 {
+		return p, []int{0}, []float64{1}
+	}
+	t := -p.DotProduct(edge) / lengthSq
+	switch {
+	case t <= 0:
+		return p, []int{0}, []float64{1}
+	case t >= 1:
+		return q, []int{1}, []float64{1}
+	}
+	return p.Add(edge.Scale(t)), []int{0, 1}, []float64{1 - t, t}
+}
+
+// barycentric returns the weights of the origin on the triangle, and whether it is inside it
+func barycentric(p, q, r vector.Vector) ([]float64, bool) {
+	area := q.Subtract(p).PerpDotProduct(r.Subtract(p))
+	if area == 0 {
+		return nil, false
+	}
+	wp := q.PerpDotProduct(r) / area
+	wq := r.PerpDotProduct(p) / area
+	wr := p.PerpDotProduct(q) / area
+	inside := wp >= 0 && wq >= 0 && wr >= 0
+	return []float64{wp, wq, wr}, inside
+}
+
+func pick(simplex []minkowskiPoint, indices []int) []minkowskiPoint {
+	picked := make([]minkowskiPoint, len(indices))
+	for i, index := range indices {
+		picked[i] = simplex[index]
+	}
+	return picked
+}
+
+// SweptAABB returns the box around a convex shape over the whole of the displacement,
+// which holds everything a shape cast could hit
+func SweptAABB(shape Convex, displacement vector.Vector) AABB {
+	points := []vector.Vector{}
+	for _, direction := range []vector.Vector{
+		vector.NewVector(1, 0), vector.NewVector(-1, 0), vector.NewVector(0, 1), vector.NewVector(0, -1),
+	} {
+		p := shape.Support(direction)
+		points = append(points, p, p.Add(displacement))
+	}
+	return aabbOfVertices(points)
+}
@@ -0,0 +1,97 @@
+package object
+
+import (
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestShapeCast(t *testing.T) {
+	Convey("Should find when a swept circle touches another circle", t, func() {
+		a := NewCircleObject(1, 1, vector.NewVector(0, 0))
+		b := NewCircleObject(1, 1, vector.NewVector(5, 0))
+		hit, ok := ShapeCast(&a, vector.NewVector(10, 0), &b)
+		So(ok, ShouldBeTrue)
+		So(hit.Fraction, ShouldAlmostEqual, 0.3, 1e-6)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1, 1e-6)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 4, 1e-3)
+	})
+
+	Convey("Should find when a swept rectangle meets the face of another", t, func() {
+		a := NewRectangleObject(10, 10, 1, vector.NewVector(0, 0))
+		b := NewRectangleObject(10, 100, 1, vector.NewVector(50, -20))
+		hit, ok := ShapeCast(&a, vector.NewVector(80, 30), &b)
+		So(ok, ShouldBeTrue)
+		So(hit.Fraction, ShouldAlmostEqual, 0.5)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+		So(hit.Normal.GetVals()[1], ShouldAlmostEqual, 0)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 50)
+	})
+
+	Convey("Should miss shapes the displacement stops short of or passes beside", t, func() {
+		a := NewCircleObject(1, 1, vector.NewVector(0, 0))
+		b := NewCircleObject(1, 1, vector.NewVector(5, 0))
+		_, ok := ShapeCast(&a, vector.NewVector(2.9, 0), &b)
+		So(ok, ShouldBeFalse)
+		_, ok = ShapeCast(&a, vector.NewVector(10, 10), &b)
+		So(ok, ShouldBeFalse)
+		_, ok = ShapeCast(&a, vector.NewVector(-10, 0), &b)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should hit shapes it already overlaps straight away", t, func() {
+		a := NewCircleObject(1, 1, vector.NewVector(0, 0))
+		b := NewCircleObject(1, 1, vector.NewVector(1.5, 0))
+		hit, ok := ShapeCast(&a, vector.NewVector(10, 0), &b)
+		So(ok, ShouldBeTrue)
+		So(hit.Fraction, ShouldEqual, 0)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1, 1e-3)
+	})
+
+	Convey("Should hit a surface it touches with the surface's normal", t, func() {
+		ball := NewCircleObject(10, 1, vector.NewVector(0, 10))
+		floor := NewRectangleObject(200, 20, 0, vector.NewVector(-100, -20))
+		hit, ok := ShapeCast(&ball, vector.NewVector(3, -1), &floor)
+		So(ok, ShouldBeTrue)
+		So(hit.Fraction, ShouldEqual, 0)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, 0, 1e-6)
+		So(hit.Normal.GetVals()[1], ShouldAlmostEqual, 1, 1e-6)
+		So(hit.Point.GetVals()[0], ShouldAlmostEqual, 0, 1e-2)
+		So(hit.Point.GetVals()[1], ShouldAlmostEqual, 0, 1e-2)
+
+		// sliding along the surface or lifting off it doesn't hit it
+		_, ok = ShapeCast(&ball, vector.NewVector(3, 0), &floor)
+		So(ok, ShouldBeFalse)
+		_, ok = ShapeCast(&ball, vector.NewVector(3, 1), &floor)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should not tunnel through a thin segment", t, func() {
+		a := NewCircleObject(1, 1, vector.NewVector(0, 0))
+		s := NewSegmentObject(vector.NewVector(50, -10), vector.NewVector(50, 10))
+		hit, ok := ShapeCast(&a, vector.NewVector(1000, 0), &s)
+		So(ok, ShouldBeTrue)
+		So(hit.Fraction, ShouldAlmostEqual, 0.049, 1e-6)
+	})
+
+	Convey("Should report the edge of a chain and the part of a compound that are hit", t, func() {
+		a := NewCircleObject(1, 1, vector.NewVector(25, 50))
+		chain, _ := NewChainObject([]vector.Vector{
+			vector.NewVector(0, 0), vector.NewVector(10, 0), vector.NewVector(20, 10), vector.NewVector(30, 10),
+		}, false)
+		hit, ok := ShapeCast(&a, vector.NewVector(0, -100), &chain)
+		So(ok, ShouldBeTrue)
+		So(hit.SubShape, ShouldEqual, 2)
+		So(hit.Fraction, ShouldAlmostEqual, 0.39, 1e-6)
+
+		left := NewCircleObject(5, 1, vector.NewVector(-10, 0))
+		right := NewCircleObject(5, 1, vector.NewVector(10, 0))
+		compound, _ := NewCompoundObject([]Part{&left, &right}, vector.NewVector(0, 0))
+		a = NewCircleObject(1, 1, vector.NewVector(10, 50))
+		hit, ok = ShapeCast(&a, vector.NewVector(0, -100), &compound)
+		So(ok, ShouldBeTrue)
+		So(hit.SubShape, ShouldEqual, 1)
+		So(hit.Fraction, ShouldAlmostEqual, 0.44, 1e-6)
+	})
+}
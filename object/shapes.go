@@ -0,0 +1,67 @@
+package object
+
+import (
+	"ganymede/vector"
+)
+
+// circleShape is a bare circle, for testing geometry that doesn't belong to an object
+type circleShape struct {
+	centre vector.Vector
+	radius float64
+}
+
+func (c circleShape) GetCollisionType() CollisionType {
+	return CollisionCircle
+}
+
+func (c circleShape) GetPosition() vector.Vector {
+	return c.centre
+}
+
+func (c circleShape) GetRadius() float64 {
+	return c.radius
+}
+
+// boxShape is a bare box, for testing geometry that doesn't belong to an object
+type boxShape struct {
+	position   vector.Vector
+	dimensions vector.Vector
+	angle      float64
+}
+
+func (b boxShape) GetCollisionType() CollisionType {
+	return CollisionBoundingBox
+}
+
+func (b boxShape) GetPosition() vector.Vector {
+	return b.position
+}
+
+func (b boxShape) GetDimensions() vector.Vector {
+	return b.dimensions
+}
+
+func (b boxShape) GetAngle() float64 {
+	return b.angle
+}
+
+// segmentShape is a bare segment, for testing the edges of chains
+type segmentShape struct {
+	start, end vector.Vector
+}
+
+func (s segmentShape) GetCollisionType() CollisionType {
+	return CollisionSegment
+}
+
+func (s segmentShape) GetPosition() vector.Vector {
+	return s.start
+}
+
+func (s segmentShape) GetEndpoints() (vector.Vector, vector.Vector) {
+	return s.start, s.end
+}
+
+func (s segmentShape) Support(direction vector.Vector) vector.Vector {
+	return supportOfVertices([]vector.Vector{s.start, s.end}, direction)
+}
@@ -0,0 +1,150 @@
+package ganymede
+
+import (
+	"ganymede/broadphase"
+	"ganymede/object"
+	"ganymede/vector"
+	"sort"
+)
+
+// RaycastResult is where a ray cast into the world meets a body
+type RaycastResult struct {
+	Body Body
+	object.RaycastHit
+}
+
+// Raycast returns where the ray from the origin in the direction first meets a body, if it does within maxDistance.
+// Bodies that aren't an object.Raycaster, and bodies the ray starts inside, are passed through.
+func (w *World) Raycast(origin, direction vector.Vector, maxDistance float64) (RaycastResult, bool) {
+	hits := w.RaycastAll(origin, direction, maxDistance)
+	if len(hits) == 0 {
+		return RaycastResult{}, false
+	}
+	return hits[0], true
+}
+
+// RaycastAll returns where the ray meets every body it passes through within maxDistance, nearest first
+func (w *World) RaycastAll(origin, direction vector.Vector, maxDistance float64) []RaycastResult {
+	hits := []RaycastResult{}
+	for _, b := range w.rayCandidates(origin, direction, maxDistance) {
+		r, ok := b.(object.Raycaster)
+		if !ok {
+			continue
+		}
+		if hit, ok := r.Raycast(origin, direction, maxDistance); ok {
+			hits = append(hits, RaycastResult{b, hit})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Fraction < hits[j].Fraction
+	})
+	return hits
+}
+
+// rayCandidates returns the bodies the broad phase finds along the ray, or every body without one
+func (w *World) rayCandidates(origin, direction vector.Vector, maxDistance float64) []Body {
+	if w.broadPhase == nil || direction.Magnitude() == 0 {
+		return w.bodies
+	}
+
+	if r, ok := w.broadPhase.(broadphase.Raycaster); ok {
+		return w.bodiesWithIDs(r.Raycast(origin, direction, maxDistance))
+	}
+	end := origin.Add(direction.Normalize().Scale(maxDistance))
+	return w.queryCandidates(object.NewAABB(origin, end))
+}
+
+// ShapeCastResult is where a shape swept through the world first touches a body
+type ShapeCastResult struct {
+	Body Body
+	object.ShapeCastHit
+}
+
+// ShapeCast sweeps a convex shape, such as a circle or rectangle, along the displacement without turning it,
+// and returns the body it touches first, if any. The shape can be a body in the world, which is never hit by itself,
+// so characters can find how far they can move before they move.
+// Bodies that aren't convex or made of convex parts are passed through.
+func (w *World) ShapeCast(shape object.Convex, displacement vector.Vector) (ShapeCastResult, bool) {
+	var best ShapeCastResult
+	found := false
+	for _, b := range w.queryCandidates(object.SweptAABB(shape, displacement)) {
+		if interface{}(b) == interface{}(shape) {
+			continue
+		}
+		hit, ok := object.ShapeCast(shape, displacement, b)
+		if ok && (!found || hit.Fraction < best.Fraction) {
+			best, found = ShapeCastResult{b, hit}, true
+		}
+	}
+	return best, found
+}
+
+// queryCandidates returns the bodies the broad phase finds overlapping the box, or every body without one
+func (w *World) queryCandidates(box object.AABB) []Body {
+	if w.broadPhase == nil {
+		return w.bodies
+	}
+	return w.bodiesWithIDs(w.broadPhase.Query(box))
+}
+
+func (w *World) bodiesWithIDs(ids []int) []Body {
+	bodies := w.bodiesByID()
+	found := make([]Body, len(ids))
+	for i, id := range ids {
+		found[i] = bodies[id]
+	}
+	return found
+}
+
+// QueryPoint returns the bodies containing the point, in the order they were added,
+// such as the body under the mouse. If the filter isn't nil, only bodies it accepts are returned.
+// Bodies that aren't an object.PointContainer, such as segments and chains, never contain points.
+func (w *World) QueryPoint(point vector.Vector, filter func(Body) bool) []Body {
+	found := []Body{}
+	for _, b := range w.queryCandidates(object.NewAABB(point)) {
+		container, ok := b.(object.PointContainer)
+		if ok && container.ContainsPoint(point) && accepts(filter, b) {
+			found = append(found, b)
+		}
+	}
+	return found
+}
+
+// QueryAABB returns the bodies whose bounding boxes overlap the box, in the order they were added.
+// If the filter isn't nil, only bodies it accepts are returned.
+// For the bodies themselves rather than their bounding boxes, query with a rectangle through QueryShape.
+func (w *World) QueryAABB(box object.AABB, filter func(Body) bool) []Body {
+	found := []Body{}
+	for _, b := range w.queryCandidates(box) {
+		if b.GetAABB().Overlaps(box) && accepts(filter, b) {
+			found = append(found, b)
+		}
+	}
+	return found
+}
+
+// QueryShape returns the bodies overlapping the shape, in the order they were added, such as those caught in an explosion.
+// If the filter isn't nil, only bodies it accepts are returned.
+// The shape can be a body in the world, which isn't returned itself.
+// Bodies that object.DetectCollision has no test for against the shape are never returned.
+func (w *World) QueryShape(shape object.Collider, filter func(Body) bool) []Body {
+	candidates := w.bodies
+	if bounded, ok := shape.(object.Bounded); ok {
+		candidates = w.queryCandidates(bounded.GetAABB())
+	}
+
+	found := []Body{}
+	for _, b := range candidates {
+		if interface{}(b) == interface{}(shape) || !accepts(filter, b) {
+			continue
+		}
+		if collided, _, err := object.DetectCollision(shape, b); err == nil && collided {
+			found = append(found, b)
+		}
+	}
+	return found
+}
+
+func accepts(filter func(Body) bool, b Body) bool {
+	return filter == nil || filter(b)
+}
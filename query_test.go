@@ -0,0 +1,166 @@
+package ganymede
+
+import (
+	"ganymede/broadphase"
+	"ganymede/object"
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRaycast(t *testing.T) {
+	scene := func(bp broadphase.BroadPhase) (*World, []Body) {
+		w := NewWorld()
+		w.SetBroadPhase(bp)
+		near := object.NewCircleObject(10, 1, vector.NewVector(50, 0))
+		far := object.NewRectangleObject(10, 100, 0, vector.NewVector(200, -50))
+		aside := object.NewCircleObject(10, 1, vector.NewVector(100, 100))
+		w.AddBody(&far)
+		w.AddBody(&near)
+		w.AddBody(&aside)
+		return w, []Body{&near, &far, &aside}
+	}
+
+	broadPhases := []struct {
+		name string
+		bp   func() broadphase.BroadPhase
+	}{
+		{"every body", func() broadphase.BroadPhase { return nil }},
+		{"a spatial hash", func() broadphase.BroadPhase { return broadphase.NewSpatialHash(50) }},
+		{"an AABB tree", func() broadphase.BroadPhase { return broadphase.NewAABBTree(5) }},
+		{"sweep and prune", func() broadphase.BroadPhase { return broadphase.NewSweepAndPrune() }},
+	}
+	for _, b := range broadPhases {
+		name, bp := b.name, b.bp
+		Convey("Should return the closest hit through "+name, t, func() {
+			w, bodies := scene(bp())
+			hit, ok := w.Raycast(vector.NewVector(0, 0), vector.NewVector(1, 0), 500)
+			So(ok, ShouldBeTrue)
+			So(hit.Body, ShouldEqual, bodies[0])
+			So(hit.Point.GetVals()[0], ShouldAlmostEqual, 40)
+		})
+
+		Convey("Should return every hit nearest first through "+name, t, func() {
+			w, bodies := scene(bp())
+			hits := w.RaycastAll(vector.NewVector(0, 0), vector.NewVector(1, 0), 500)
+			So(len(hits), ShouldEqual, 2)
+			So(hits[0].Body, ShouldEqual, bodies[0])
+			So(hits[1].Body, ShouldEqual, bodies[1])
+			So(hits[1].Fraction, ShouldAlmostEqual, 0.4)
+		})
+	}
+
+	Convey("Should find nothing when the line of sight is clear", t, func() {
+		w, _ := scene(broadphase.NewAABBTree(5))
+		_, ok := w.Raycast(vector.NewVector(0, 50), vector.NewVector(1, 0), 150)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should probe for the ground below a body without hitting the body", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(10, 1, vector.NewVector(400, 130))
+		platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, 0))
+		w.AddBody(&ball)
+		w.AddBody(&platform)
+
+		hit, ok := w.Raycast(ball.GetPosition(), vector.NewVector(0, -1), 100)
+		So(ok, ShouldBeTrue)
+		So(hit.Body, ShouldEqual, &platform)
+		So(hit.Point.GetVals()[1], ShouldAlmostEqual, 100)
+	})
+}
+
+func TestShapeCast(t *testing.T) {
+	room := func(bp broadphase.BroadPhase) (*World, *object.Circle, *object.Rectangle) {
+		w := NewWorld()
+		w.SetBroadPhase(bp)
+		player := object.NewCircleObject(10, 1, vector.NewVector(100, 50))
+		wall := object.NewRectangleObject(20, 200, 0, vector.NewVector(200, 0))
+		floor := object.NewRectangleObject(400, 20, 0, vector.NewVector(0, 0))
+		w.AddBody(&player)
+		w.AddBody(&wall)
+		w.AddBody(&floor)
+		return w, &player, &wall
+	}
+
+	Convey("Should find the wall a character walks into, without hitting the character", t, func() {
+		w, player, wall := room(nil)
+		hit, ok := w.ShapeCast(player, vector.NewVector(200, 0))
+		So(ok, ShouldBeTrue)
+		So(hit.Body, ShouldEqual, wall)
+		So(hit.Fraction, ShouldAlmostEqual, 0.45)
+		So(hit.Normal.GetVals()[0], ShouldAlmostEqual, -1)
+	})
+
+	Convey("Should let a character slide along the wall instead of tunnelling through it", t, func() {
+		w, player, _ := room(broadphase.NewAABBTree(5))
+		move := vector.NewVector(200, 100)
+		hit, ok := w.ShapeCast(player, move)
+		So(ok, ShouldBeTrue)
+
+		player.AdjustPosition(move.Scale(hit.Fraction))
+		remaining := move.Scale(1 - hit.Fraction)
+		remaining = remaining.Subtract(hit.Normal.Scale(remaining.DotProduct(hit.Normal)))
+		_, ok = w.ShapeCast(player, remaining)
+		So(ok, ShouldBeFalse)
+
+		player.AdjustPosition(remaining)
+		So(player.GetPosition().GetVals()[0], ShouldAlmostEqual, 190, 1e-6)
+		So(player.GetPosition().GetVals()[1], ShouldAlmostEqual, 150, 1e-6)
+	})
+
+	Convey("Should find nothing in open space", t, func() {
+		w, player, _ := room(broadphase.NewSpatialHash(50))
+		_, ok := w.ShapeCast(player, vector.NewVector(-50, 0))
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestQueries(t *testing.T) {
+	scene := func(bp broadphase.BroadPhase) (*World, []Body) {
+		w := NewWorld()
+		w.SetBroadPhase(bp)
+		ball := object.NewCircleObject(10, 1, vector.NewVector(50, 50))
+		crate := object.NewRectangleObject(20, 20, 1, vector.NewVector(40, 40))
+		floor := object.NewRectangleObject(400, 20, 0, vector.NewVector(0, 0))
+		ground := object.NewSegmentObject(vector.NewVector(0, 20), vector.NewVector(400, 20))
+		bodies := []Body{&ball, &crate, &floor, &ground}
+		for _, b := range bodies {
+			w.AddBody(b)
+		}
+		return w, bodies
+	}
+	dynamic := func(b Body) bool {
+		return b.GetKind() == object.Dynamic
+	}
+
+	Convey("Should find the bodies containing a point", t, func() {
+		w, bodies := scene(broadphase.NewAABBTree(5))
+		So(w.QueryPoint(vector.NewVector(50, 50), nil), ShouldResemble, bodies[:2])
+		So(w.QueryPoint(vector.NewVector(58, 58), nil), ShouldResemble, bodies[1:2])
+		So(w.QueryPoint(vector.NewVector(200, 10), nil), ShouldResemble, bodies[2:3])
+		So(w.QueryPoint(vector.NewVector(200, 100), nil), ShouldBeEmpty)
+	})
+
+	Convey("Should find the bodies whose bounding boxes overlap a box", t, func() {
+		w, bodies := scene(nil)
+		So(w.QueryAABB(object.NewAABB(vector.NewVector(0, 15), vector.NewVector(42, 30)), nil), ShouldResemble, bodies[2:])
+
+		box := object.NewAABB(vector.NewVector(0, 15), vector.NewVector(42, 42))
+		So(w.QueryAABB(box, nil), ShouldResemble, bodies)
+		So(w.QueryAABB(box, dynamic), ShouldResemble, bodies[:2])
+	})
+
+	Convey("Should find the bodies overlapping a shape", t, func() {
+		w, bodies := scene(broadphase.NewSpatialHash(50))
+		blast := object.NewCircleObject(15, 0, vector.NewVector(70, 50))
+		So(w.QueryShape(&blast, nil), ShouldResemble, bodies[:2])
+		So(w.QueryShape(&blast, func(b Body) bool { return b != bodies[0] }), ShouldResemble, bodies[1:2])
+	})
+
+	Convey("Should not find the shape queried with among the bodies", t, func() {
+		w, bodies := scene(nil)
+		So(w.QueryShape(bodies[0], dynamic), ShouldResemble, bodies[1:2])
+	})
+}
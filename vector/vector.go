@@ -80,6 +80,23 @@ func (v1 Vector) CrossProduct(v2 Vector) Vector {
 	return Vector{}
 }
 
+// PerpDotProduct returns the magnitude of the cross product of 2 2D vectors.
+// It is positive when v2 is anti-clockwise from v1.
+func (v1 Vector) PerpDotProduct(v2 Vector) float64 {
+	if len(v1.vals) != 2 || len(v2.vals) != 2 {
+		panic("Perp dot product only implemented for 2D vectors")
+	}
+	return v1.vals[0]*v2.vals[1] - v1.vals[1]*v2.vals[0]
+}
+
+// Perpendicular returns the 2D vector rotated a quarter turn anti-clockwise
+func (v1 Vector) Perpendicular() Vector {
+	if len(v1.vals) != 2 {
+		panic("Perpendicular only implemented for 2D vectors")
+	}
+	return Vector{vals: []float64{-v1.vals[1], v1.vals[0]}}
+}
+
 // DotProduct performs the dot product of 2 vectors and returns the result
 func (v1 Vector) DotProduct(v2 Vector) float64 {
 	if len(v1.vals) != len(v2.vals) {
@@ -93,6 +110,21 @@ func (v1 Vector) DotProduct(v2 Vector) float64 {
 	return res
 }
 
+// Magnitude returns the length of the vector
+func (v1 Vector) Magnitude() float64 {
+	return math.Sqrt(v1.DotProduct(v1))
+}
+
+// Normalize returns a vector in the same direction with a magnitude of 1.
+// The zero vector is returned unchanged.
+func (v1 Vector) Normalize() Vector {
+	m := v1.Magnitude()
+	if m == 0 {
+		return v1
+	}
+	return v1.Scale(1 / m)
+}
+
 // RotateAboutTail rotates the vector about its tail
 func (v1 Vector) RotateAboutTail(clockWiseAngleInRadians float64) Vector {
 	if len(v1.vals) != 2 {
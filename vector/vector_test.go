@@ -48,6 +48,37 @@ func TestVector(t *testing.T) {
 		So(res, ShouldEqual, 66)
 	})
 
+	Convey("Should get the perp dot product of 2D vectors", t, func() {
+		v1 := Vector{vals: []float64{1, 0}}
+		v2 := Vector{vals: []float64{0, 2}}
+		So(v1.PerpDotProduct(v2), ShouldEqual, 2)
+		So(v2.PerpDotProduct(v1), ShouldEqual, -2)
+	})
+
+	Convey("Should get the perpendicular of a 2D vector", t, func() {
+		v1 := Vector{vals: []float64{3, 1}}
+		res := v1.Perpendicular()
+		So(res.vals[0], ShouldEqual, -1)
+		So(res.vals[1], ShouldEqual, 3)
+	})
+
+	Convey("Should get the magnitude of a vector", t, func() {
+		v1 := Vector{vals: []float64{-3, 4}}
+		So(v1.Magnitude(), ShouldEqual, 5)
+	})
+
+	Convey("Should normalize a vector", t, func() {
+		v1 := Vector{vals: []float64{-3, 4}}
+		res := v1.Normalize()
+		So(res.vals[0], ShouldAlmostEqual, -0.6)
+		So(res.vals[1], ShouldAlmostEqual, 0.8)
+
+		v2 := Vector{vals: []float64{0, 0}}
+		res = v2.Normalize()
+		So(res.vals[0], ShouldEqual, 0)
+		So(res.vals[1], ShouldEqual, 0)
+	})
+
 	Convey("Should rotate a vector", t, func() {
 		v1 := Vector{vals: []float64{2, 2}}
 		res1 := v1.RotateAboutTail(-math.Pi / 2)
@@ -0,0 +1,268 @@
+package ganymede
+
+import (
+	"ganymede/broadphase"
+	"ganymede/force"
+	"ganymede/integrator"
+	"ganymede/object"
+	"ganymede/vector"
+)
+
+// Body is implemented by all objects that can be simulated in a world
+type Body interface {
+	object.Object
+	object.Bounded
+	GetCollisionType() object.CollisionType
+	IsBullet() bool
+	AdjustPosition(vector.Vector)
+	GetIntegrator() integrator.Integrator
+	IntegrateWith(integrator.Integrator, float64, integrator.AccelerationFunc)
+}
+
+const (
+	defaultSolverIterations     = 10
+	defaultRestitutionThreshold = 1
+	defaultCorrectionPercent    = 0.4
+	defaultCorrectionSlop       = 0.01
+)
+
+// NewWorld creates an empty world that integrates with semi-implicit Euler
+func NewWorld() *World {
+	return &World{
+		ids:                  map[Body]int{},
+		integrator:           integrator.SemiImplicitEuler{},
+		solverIterations:     defaultSolverIterations,
+		restitutionThreshold: defaultRestitutionThreshold,
+		correctionPercent:    defaultCorrectionPercent,
+		correctionSlop:       defaultCorrectionSlop,
+	}
+}
+
+// World holds bodies and the global forces acting on them
+type World struct {
+	bodies     []Body
+	forces     []force.Field
+	integrator integrator.Integrator
+
+	solverIterations     int
+	restitutionThreshold float64
+	correctionPercent    float64
+	correctionSlop       float64
+
+	contacts map[contactKey]*contact // from the previous step
+
+	broadPhase broadphase.BroadPhase
+	ids        map[Body]int // in the order the bodies were added, which keeps the broad phase's pairs in the same order
+	nextID     int
+}
+
+// SetSolverIterations sets how many times per step the contact solver passes over every contact,
+// both to resolve velocities and to correct overlaps.
+// More iterations make stacks and chains of contacts more accurate.
+func (w *World) SetSolverIterations(iterations int) {
+	w.solverIterations = iterations
+}
+
+// SetRestitutionThreshold sets the speed below which contacts don't bounce,
+// so resting bodies settle instead of jittering
+func (w *World) SetRestitutionThreshold(speed float64) {
+	w.restitutionThreshold = speed
+}
+
+// SetPositionCorrection controls how overlapping bodies are pushed apart after contacts are solved.
+// Each step removes the percentage (between 0 and 1) of each contact's overlap beyond the slop,
+// however many solver iterations there are; the iterations only spread the corrections through stacks.
+// Leaving some slop and correcting gradually keeps resting bodies from jittering.
+func (w *World) SetPositionCorrection(percent, slop float64) {
+	w.correctionPercent = percent
+	w.correctionSlop = slop
+}
+
+// SetIntegrator chooses the integration scheme for bodies that haven't chosen their own
+func (w *World) SetIntegrator(in integrator.Integrator) {
+	w.integrator = in
+}
+
+// SetBroadPhase chooses how the world finds the pairs of bodies that might be colliding.
+// Without one, every pair of bodies is tested, which is fine for a handful of bodies.
+// The bodies are taken out of the broad phase the world had before, so setting the same one again is harmless.
+// Every body's bounding box, static bodies' included, is brought up to date in the broad phase once per step,
+// so bodies moved between steps are found where they were until the next step.
+func (w *World) SetBroadPhase(bp broadphase.BroadPhase) {
+	if w.broadPhase != nil {
+		for _, b := range w.bodies {
+			w.broadPhase.Remove(w.ids[b])
+		}
+	}
+	w.broadPhase = bp
+	if bp == nil {
+		return
+	}
+	for _, b := range w.bodies {
+		bp.Insert(w.ids[b], b.GetAABB())
+	}
+}
+
+// AddBody adds a body to the world
+func (w *World) AddBody(b Body) {
+	w.bodies = append(w.bodies, b)
+	w.ids[b] = w.nextID
+	w.nextID++
+	if w.broadPhase != nil {
+		w.broadPhase.Insert(w.ids[b], b.GetAABB())
+	}
+}
+
+// RemoveBody removes a body from the world
+func (w *World) RemoveBody(b Body) {
+	for i, existing := range w.bodies {
+		if existing == b {
+			w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+			if w.broadPhase != nil {
+				w.broadPhase.Remove(w.ids[b])
+			}
+			delete(w.ids, b)
+			return
+		}
+	}
+}
+
+// GetBodies returns the bodies in the world
+func (w *World) GetBodies() []Body {
+	return w.bodies
+}
+
+// AddForce adds a global force that acts on every body in the world
+func (w *World) AddForce(f force.Field) {
+	w.forces = append(w.forces, f)
+}
+
+// Step advances the world by dt.
+// The bodies are integrated over dt under the global forces, with their
+// own integrator or else the world's. Kinematic bodies move at their velocity
+// and static bodies don't move.
+// Every pair of bodies is then tested for collision, and the contacts are resolved
+// with impulses that account for the mass, restitution and friction of both bodies.
+// Finally overlapping bodies are pushed apart in proportion to their inverse masses.
+// Bullets are swept along the path they took between integration and testing for collisions,
+// so that they stop at anything in their way.
+// Pairs of bodies that object.DetectCollisions has no test for pass through each other,
+// and the step returns the error for the first of them once it has finished.
+func (w *World) Step(dt float64) error {
+	starts := w.positionsBeforeStep()
+	for _, b := range w.bodies {
+		switch b.GetKind() {
+		case object.Static:
+			continue
+		case object.Kinematic:
+			b.Integrate(dt)
+			continue
+		}
+
+		in := b.GetIntegrator()
+		if in == nil {
+			in = w.integrator
+		}
+		b.IntegrateWith(in, dt, w.forceField(b))
+	}
+	if w.broadPhase != nil {
+		// static bodies don't move by themselves, but can be moved between steps
+		for _, b := range w.bodies {
+			w.broadPhase.Update(w.ids[b], b.GetAABB())
+		}
+	}
+	if starts != nil {
+		w.sweepBullets(starts, dt)
+	}
+
+	contacts, err := w.findContacts()
+	w.solveContacts(contacts)
+	for i := 0; i < w.solverIterations; i++ {
+		for _, c := range contacts {
+			c.correctPosition(w.correctionPercent, w.correctionSlop)
+		}
+	}
+	return err
+}
+
+// findContacts tests every pair of bodies where at least one is dynamic,
+// or only the pairs from the broad phase if there is one,
+// with a contact for each pair of their parts that touch.
+// Pairs that object.DetectCollisions has no test for are skipped, and the first of their errors is returned.
+func (w *World) findContacts() ([]*contact, error) {
+	contacts := []*contact{}
+	var firstErr error
+	for _, pair := range w.candidatePairs() {
+		a, b := pair[0], pair[1]
+		if a.GetKind() != object.Dynamic && b.GetKind() != object.Dynamic {
+			continue
+		}
+
+		manifolds, err := object.DetectCollisions(a, b)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		for _, m := range manifolds {
+			contacts = append(contacts, newContact(a, b, m))
+		}
+	}
+	return contacts, firstErr
+}
+
+// candidatePairs returns the pairs of bodies that might be colliding, each in the order they were added
+func (w *World) candidatePairs() [][2]Body {
+	pairs := [][2]Body{}
+	if w.broadPhase == nil {
+		for i, a := range w.bodies {
+			for _, b := range w.bodies[i+1:] {
+				pairs = append(pairs, [2]Body{a, b})
+			}
+		}
+		return pairs
+	}
+
+	bodies := w.bodiesByID()
+	for _, p := range w.broadPhase.Pairs() {
+		pairs = append(pairs, [2]Body{bodies[p.A], bodies[p.B]})
+	}
+	return pairs
+}
+
+// bodiesByID looks up bodies by the ids the broad phase knows them by
+func (w *World) bodiesByID() map[int]Body {
+	bodies := make(map[int]Body, len(w.bodies))
+	for _, b := range w.bodies {
+		bodies[w.ids[b]] = b
+	}
+	return bodies
+}
+
+func (w *World) solveContacts(contacts []*contact) {
+	previous := w.contacts
+	w.contacts = map[contactKey]*contact{}
+	for _, c := range contacts {
+		c.prepare(w.restitutionThreshold)
+		c.warmStart(previous[c.key])
+		w.contacts[c.key] = c
+	}
+	for i := 0; i < w.solverIterations; i++ {
+		for _, c := range contacts {
+			c.solve()
+		}
+	}
+}
+
+// forceField sums the global forces acting on the body and converts them to an acceleration
+func (w *World) forceField(b Body) integrator.AccelerationFunc {
+	return func(s integrator.State) vector.Vector {
+		sum := vector.NewVector(make([]float64, len(s.Position.GetVals()))...)
+		for _, f := range w.forces {
+			sum = sum.Add(f(b, s))
+		}
+		return sum.Scale(b.GetInverseMass())
+	}
+}
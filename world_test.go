@@ -0,0 +1,236 @@
+package ganymede
+
+import (
+	"errors"
+	"ganymede/broadphase"
+	"ganymede/force"
+	"ganymede/integrator"
+	"ganymede/object"
+	"ganymede/vector"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWorld(t *testing.T) {
+	Convey("Should apply global forces to dynamic bodies", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(10, 1, vector.NewVector(100, 100))
+		w.AddBody(&ball)
+		w.AddForce(force.Gravity(vector.NewVector(0, -1)))
+
+		w.Step(1)
+		So(ball.GetPosition().GetVals()[1], ShouldBeLessThan, 100)
+		So(ball.GetVelocity().GetVals()[1], ShouldEqual, -1)
+	})
+
+	Convey("Should push lighter bodies further with the same force", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(10, 1, vector.NewVector(0, 0))
+		crate := object.NewRectangleObject(10, 10, 100, vector.NewVector(0, 100))
+		w.AddBody(&ball)
+		w.AddBody(&crate)
+		w.AddForce(force.Constant(vector.NewVector(10, 0)))
+
+		w.Step(1)
+		So(ball.GetVelocity().GetVals()[0], ShouldEqual, 10)
+		So(crate.GetVelocity().GetVals()[0], ShouldEqual, 0.1)
+	})
+
+	Convey("Should not move static bodies", t, func() {
+		w := NewWorld()
+		platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, 0))
+		w.AddBody(&platform)
+		w.AddForce(force.Gravity(vector.NewVector(0, -1)))
+
+		for i := 0; i < 10; i++ {
+			w.Step(1)
+		}
+		So(platform.GetPosition().GetVals()[0], ShouldEqual, 0)
+		So(platform.GetPosition().GetVals()[1], ShouldEqual, 0)
+	})
+
+	Convey("Should stop a falling ball at the platform", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(20, 1, vector.NewVector(400, 400))
+		platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, 0))
+		w.AddBody(&ball)
+		w.AddBody(&platform)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		for i := 0; i < 300; i++ {
+			w.Step(1.0 / 60)
+			So(ball.GetPosition().GetVals()[1], ShouldBeGreaterThan, 70)
+		}
+	})
+
+	Convey("Should move kinematic bodies without gravity", t, func() {
+		w := NewWorld()
+		lift := object.NewRectangleObject(100, 10, 10, vector.NewVector(0, 0))
+		lift.SetKind(object.Kinematic)
+		lift.SetVelocity(vector.NewVector(0, 5))
+		w.AddBody(&lift)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		w.Step(1)
+		So(lift.GetPosition().GetVals()[1], ShouldEqual, 5)
+		So(lift.GetVelocity().GetVals()[1], ShouldEqual, 5)
+	})
+
+	Convey("Should not move bodies made static", t, func() {
+		w := NewWorld()
+		crate := object.NewRectangleObject(10, 10, 10, vector.NewVector(0, 100))
+		crate.SetKind(object.Static)
+		w.AddBody(&crate)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		w.Step(1)
+		So(crate.GetPosition().GetVals()[1], ShouldEqual, 100)
+	})
+
+	Convey("Should remove bodies", t, func() {
+		w := NewWorld()
+		c1 := object.NewCircleObject(10, 1, vector.NewVector(0, 0))
+		c2 := object.NewCircleObject(10, 1, vector.NewVector(50, 0))
+		w.AddBody(&c1)
+		w.AddBody(&c2)
+		w.RemoveBody(&c1)
+		So(len(w.GetBodies()), ShouldEqual, 1)
+		So(w.GetBodies()[0], ShouldEqual, &c2)
+	})
+
+	Convey("Should report pairs of bodies it has no collision test for", t, func() {
+		w := NewWorld()
+		ball := object.NewCircleObject(10, 1, vector.NewVector(0, 0))
+		stranger := unknownBody{&ball}
+		crate := object.NewRectangleObject(10, 10, 1, vector.NewVector(5, 0))
+		w.AddBody(stranger)
+		w.AddBody(&crate)
+
+		err := w.Step(1.0 / 60)
+		So(errors.Is(err, object.ErrUnknownCollision), ShouldBeTrue)
+		So(w.Step(1.0/60), ShouldNotBeNil)
+
+		w.RemoveBody(stranger)
+		So(w.Step(1.0/60), ShouldBeNil)
+	})
+}
+
+// unknownBody hides a body's shape behind a collision type nothing is registered for
+type unknownBody struct {
+	Body
+}
+
+var unknownCollision = object.NewCollisionType()
+
+func (unknownBody) GetCollisionType() object.CollisionType {
+	return unknownCollision
+}
+
+func TestWorldBroadPhase(t *testing.T) {
+	pile := func(bp broadphase.BroadPhase) []vector.Vector {
+		w := NewWorld()
+		w.SetBroadPhase(bp)
+		platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, 0))
+		w.AddBody(&platform)
+		balls := make([]object.Circle, 20)
+		for i := range balls {
+			balls[i] = object.NewCircleObject(10, 1, vector.NewVector(300+float64(i%5)*15, 150+float64(i/5)*25))
+			w.AddBody(&balls[i])
+		}
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		for i := 0; i < 120; i++ {
+			w.Step(1.0 / 60)
+		}
+		positions := make([]vector.Vector, len(balls))
+		for i, b := range balls {
+			positions[i] = b.GetPosition()
+		}
+		return positions
+	}
+
+	Convey("Should simulate the same with a broad phase as testing every pair", t, func() {
+		So(pile(broadphase.NewSpatialHash(50)), ShouldResemble, pile(nil))
+		So(pile(broadphase.NewAABBTree(5)), ShouldResemble, pile(nil))
+		So(pile(broadphase.NewSweepAndPrune()), ShouldResemble, pile(nil))
+	})
+
+	Convey("Should stop colliding with removed bodies", t, func() {
+		w := NewWorld()
+		w.SetBroadPhase(broadphase.NewSpatialHash(50))
+		ball := object.NewCircleObject(20, 1, vector.NewVector(400, 130))
+		platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, 0))
+		w.AddBody(&ball)
+		w.AddBody(&platform)
+		w.RemoveBody(&platform)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+
+		for i := 0; i < 60; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(ball.GetPosition().GetVals()[1], ShouldBeLessThan, 0)
+	})
+
+	Convey("Should hold each body once when the broad phase is set again", t, func() {
+		w := NewWorld()
+		bp := broadphase.NewAABBTree(5)
+		ball := object.NewCircleObject(20, 1, vector.NewVector(400, 130))
+		platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, 0))
+		w.AddBody(&ball)
+		w.AddBody(&platform)
+		w.SetBroadPhase(bp)
+		w.SetBroadPhase(bp)
+		So(bp.Query(object.NewAABB(vector.NewVector(0, 0), vector.NewVector(800, 200))), ShouldResemble, []int{0, 1})
+
+		w.SetBroadPhase(broadphase.NewSpatialHash(50))
+		So(bp.Query(object.NewAABB(vector.NewVector(0, 0), vector.NewVector(800, 200))), ShouldBeEmpty)
+	})
+
+	Convey("Should follow static bodies moved between steps", t, func() {
+		w := NewWorld()
+		w.SetBroadPhase(broadphase.NewSpatialHash(50))
+		ball := object.NewCircleObject(20, 1, vector.NewVector(400, 130))
+		platform := object.NewRectangleObject(800, 100, 0, vector.NewVector(0, -1000))
+		w.AddBody(&ball)
+		w.AddBody(&platform)
+		w.AddForce(force.Gravity(vector.NewVector(0, -900)))
+		w.Step(1.0 / 60)
+
+		platform.AdjustPosition(vector.NewVector(0, 1000))
+		for i := 0; i < 60; i++ {
+			w.Step(1.0 / 60)
+		}
+		So(ball.GetPosition().GetVals()[1], ShouldBeGreaterThan, 100)
+		So(w.QueryAABB(object.NewAABB(vector.NewVector(0, 0), vector.NewVector(10, 10)), nil), ShouldResemble, []Body{&platform})
+	})
+}
+
+func TestWorldIntegrators(t *testing.T) {
+	orbitRadius := func(in integrator.Integrator, override bool) float64 {
+		w := NewWorld()
+		moon := object.NewCircleObject(1, 1, vector.NewVector(100, 0))
+		moon.SetVelocity(vector.NewVector(0, 10))
+		w.AddBody(&moon)
+		w.AddForce(force.Attractor(vector.NewVector(0, 0), 10000))
+		if override {
+			moon.SetIntegrator(in)
+		} else {
+			w.SetIntegrator(in)
+		}
+
+		for i := 0; i < 10000; i++ {
+			w.Step(0.1)
+		}
+		return moon.GetPosition().Magnitude()
+	}
+
+	Convey("Should keep a body in orbit with the world's integrator", t, func() {
+		So(orbitRadius(integrator.VelocityVerlet{}, false), ShouldAlmostEqual, 100, 0.1)
+		So(orbitRadius(integrator.Euler{}, false), ShouldBeGreaterThan, 150)
+	})
+
+	Convey("Should prefer a body's own integrator over the world's", t, func() {
+		So(orbitRadius(integrator.RK4{}, true), ShouldAlmostEqual, 100, 0.1)
+	})
+}